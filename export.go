@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/connorkuehl/wording/internal/export"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// exportGames writes every game, its plays and the lifetime stats as JSON
+// Lines, for backups and for moving to another instance.
+func (a *app) exportGames(ctx context.Context, args []string) (err error) {
+	fs := a.flagSet("export")
+	output := fs.String("o", "-", "Write to this file instead of stdout")
+
+	err = fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return a.usageError("export: unexpected arguments %q", fs.Args())
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	out := a.stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			closeErr := f.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}()
+		out = f
+	}
+
+	w := export.NewWriter(out)
+
+	games := 0
+	err = svc.ExportGames(ctx, func(game *wording.GameRecord) error {
+		games++
+		return w.WriteGame(game)
+	})
+	if err != nil {
+		return err
+	}
+
+	stats, err := svc.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = w.WriteStats(stats)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stderr, "exported %d games\n", games)
+
+	return nil
+}
+
+// importGames reads what exportGames wrote. Records that don't validate
+// are reported and skipped so one bad game doesn't hold up the rest.
+func (a *app) importGames(ctx context.Context, args []string) error {
+	fs := a.flagSet("import")
+	freshTokens := fs.Bool("fresh-tokens", false, "Give every game new tokens instead of replacing games with the same ones")
+	withStats := fs.Bool("stats", false, "Overwrite the lifetime stats with the exported ones")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 1 {
+		return a.usageError("import: expected at most one file")
+	}
+
+	in := a.stdin
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	var imported, rejected int
+
+	r := export.NewReader(in)
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, export.ErrUnsupported) {
+			a.log.WithError(err).Warn("skipping record")
+			rejected++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case rec.Game != nil:
+			game, err := svc.ImportGame(ctx, rec.Game, *freshTokens)
+			if err != nil {
+				a.log.WithError(err).WithField("record", rec.Number).Warn("skipping game")
+				rejected++
+				continue
+			}
+
+			imported++
+			if *freshTokens {
+				fmt.Fprintf(a.stdout, "%s -> %s\n", rec.Game.AdminToken, game.AdminToken)
+			}
+		case rec.Stats != nil && *withStats:
+			err := svc.SetStats(ctx, *rec.Stats)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", rec.Number, err)
+			}
+		}
+	}
+
+	fmt.Fprintf(a.stderr, "imported %d games, skipped %d records\n", imported, rejected)
+
+	if rejected > 0 {
+		return fmt.Errorf("%d records could not be imported", rejected)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// game dispatches the game subcommands.
+func (a *app) game(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return a.usageError("game: missing subcommand")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "create":
+		return a.gameCreate(ctx, args)
+	case "show":
+		return a.gameShow(ctx, args)
+	case "delete":
+		return a.gameDelete(ctx, args)
+	default:
+		return a.usageError("game: unknown subcommand %q", cmd)
+	}
+}
+
+func (a *app) gameCreate(ctx context.Context, args []string) error {
+	fs := a.flagSet("game create")
+	answer := fs.String("answer", "", "The word players have to guess")
+	limit := fs.Int("limit", 6, "How many guesses each player gets")
+	opensAt := fs.String("opens-at", "", "When players can start guessing, in RFC 3339 (default now)")
+	closesAt := fs.String("closes-at", "", "When players have to stop guessing, in RFC 3339 (default never)")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return a.usageError("game create: unexpected arguments %q", fs.Args())
+	}
+
+	var settings wording.GameSettings
+	if *opensAt != "" {
+		settings.OpensAt, err = time.Parse(time.RFC3339, *opensAt)
+		if err != nil {
+			return a.usageError("game create: -opens-at must be an RFC 3339 time")
+		}
+	}
+	if *closesAt != "" {
+		settings.ClosesAt, err = time.Parse(time.RFC3339, *closesAt)
+		if err != nil {
+			return a.usageError("game create: -closes-at must be an RFC 3339 time")
+		}
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	game, err := svc.CreateGame(ctx, *answer, *limit, settings, "")
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 1, ' ', 0)
+	a.printLinks(w, game)
+	printDifficulty(w, game)
+	return w.Flush()
+}
+
+func (a *app) gameShow(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return a.usageError("game show: expected exactly one admin token")
+	}
+	adminToken := args[0]
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	game, err := svc.Game(ctx, adminToken)
+	if err != nil {
+		return fmt.Errorf("game %s: %w", adminToken, err)
+	}
+
+	stats, err := svc.GameStats(ctx, adminToken)
+	if err != nil {
+		return fmt.Errorf("game %s stats: %w", adminToken, err)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 1, ' ', 0)
+	a.printLinks(w, game)
+	fmt.Fprintf(w, "Answer:\t%s\n", game.Answer)
+	fmt.Fprintf(w, "Guess limit:\t%d\n", game.GuessLimit)
+	printDifficulty(w, game)
+	printStats(w, stats.Stats)
+	printGameStats(w, stats)
+	return w.Flush()
+}
+
+func (a *app) gameDelete(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return a.usageError("game delete: expected at least one admin token")
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	for _, adminToken := range args {
+		err := svc.DeleteGame(ctx, adminToken)
+		if err != nil {
+			return fmt.Errorf("game %s: %w", adminToken, err)
+		}
+
+		fmt.Fprintf(a.stdout, "deleted %s\n", adminToken)
+	}
+
+	return nil
+}
+
+// printLinks prints the same links the manage page shows.
+func (a *app) printLinks(w *tabwriter.Writer, game *wording.Game) {
+	fmt.Fprintf(w, "Admin link:\t%s/manage/%s\n", a.cfg.BaseURL, game.AdminToken)
+	fmt.Fprintf(w, "Player link:\t%s/game/%s\n", a.cfg.BaseURL, game.Token)
+}
+
+func printDifficulty(w *tabwriter.Writer, game *wording.Game) {
+	d := game.Difficulty
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "Difficulty:\t%d/100 (%s), about %d guesses\n", d.Score, d.Label(), d.ExpectedGuesses())
+	if d.LimitTooLow(game.GuessLimit) {
+		fmt.Fprintf(w, "Warning:\tmost players will probably run out of guesses\n")
+	}
+}
+
+func printStats(w *tabwriter.Writer, stats wording.Stats) {
+	fmt.Fprintf(w, "Games created:\t%d\n", stats.GamesCreated)
+	fmt.Fprintf(w, "Guesses made:\t%d\n", stats.GuessesMade)
+	fmt.Fprintf(w, "Games won:\t%d\n", stats.GamesWon)
+}
+
+func printGameStats(w *tabwriter.Writer, stats wording.GameStats) {
+	fmt.Fprintf(w, "Players:\t%d started, %d finished, %d lost\n", stats.PlayersStarted, stats.PlayersFinished, stats.Losses)
+	for i, n := range stats.WinsIn {
+		fmt.Fprintf(w, "Won in %d:\t%d\n", i+1, n)
+	}
+	fmt.Fprintf(w, "First guesses:\t%s\n", formatWordCounts(stats.FirstGuesses))
+	fmt.Fprintf(w, "Wrong final guesses:\t%s\n", formatWordCounts(stats.WrongFinalGuesses))
+}
+
+func formatWordCounts(words []wording.WordCount) string {
+	if len(words) == 0 {
+		return "none yet"
+	}
+
+	s := make([]string, len(words))
+	for i, w := range words {
+		s[i] = fmt.Sprintf("%s (%d)", w.Word, w.Count)
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,390 @@
+// Package api defines the JSON API that non-browser clients use to play
+// games, and a client for it.
+//
+// The API identifies players with a signed token in the X-Wording-Player
+// header instead of a cookie. The server issues one in the response if the
+// request didn't carry a valid one, and clients send it back on every
+// request to keep their progress.
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// PlayerHeader carries the signed player token in both directions.
+const PlayerHeader = "X-Wording-Player"
+
+// Character is a letter of a guess.
+type Character struct {
+	Value   string `json:"value"`
+	Correct bool   `json:"correct"`
+	Partial bool   `json:"partial"`
+}
+
+// GameState is a player's progress against a game.
+type GameState struct {
+	Attempts    [][]Character `json:"attempts"`
+	CanContinue bool          `json:"can_continue"`
+	Victorious  bool          `json:"victorious"`
+	GameOver    bool          `json:"game_over"`
+	// Answer is only included once the game's reveal setting allows it.
+	Answer string `json:"answer,omitempty"`
+}
+
+// Game is a game as seen by a player. Its state only includes the answer
+// once the player is allowed to see it.
+type Game struct {
+	Token      string    `json:"token"`
+	Length     int       `json:"length"`
+	GuessLimit int       `json:"guess_limit"`
+	State      GameState `json:"state"`
+	// OpensAt and ClosesAt are only set if the game is scheduled.
+	OpensAt  *time.Time `json:"opens_at,omitempty"`
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+}
+
+// CreateGameRequest creates a game. OpensAt and ClosesAt optionally
+// schedule when it can be played, and Reveal is "loss" (default), "close"
+// or "never".
+type CreateGameRequest struct {
+	Answer     string     `json:"answer"`
+	GuessLimit int        `json:"guess_limit"`
+	OpensAt    *time.Time `json:"opens_at,omitempty"`
+	ClosesAt   *time.Time `json:"closes_at,omitempty"`
+	Reveal     string     `json:"reveal,omitempty"`
+}
+
+// Settings returns the settings the game was requested with.
+func (r CreateGameRequest) Settings() wording.GameSettings {
+	settings := wording.GameSettings{Reveal: wording.Reveal(r.Reveal)}
+	if r.OpensAt != nil {
+		settings.OpensAt = *r.OpensAt
+	}
+	if r.ClosesAt != nil {
+		settings.ClosesAt = *r.ClosesAt
+	}
+	return settings
+}
+
+// FromSchedule converts a game's schedule for the API, leaving out the ends
+// that are open.
+func FromSchedule(settings wording.GameSettings) (opensAt, closesAt *time.Time) {
+	if !settings.OpensAt.IsZero() {
+		t := settings.OpensAt.UTC()
+		opensAt = &t
+	}
+	if !settings.ClosesAt.IsZero() {
+		t := settings.ClosesAt.UTC()
+		closesAt = &t
+	}
+	return opensAt, closesAt
+}
+
+// CreatedGame is a newly created game. The admin link is a secret that
+// lets its holder manage the game.
+type CreatedGame struct {
+	AdminToken string      `json:"admin_token"`
+	Token      string      `json:"token"`
+	AdminURL   string      `json:"admin_url"`
+	PlayerURL  string      `json:"player_url"`
+	GuessLimit int         `json:"guess_limit"`
+	Difficulty *Difficulty `json:"difficulty,omitempty"`
+}
+
+// Difficulty is how hard an answer was rated.
+type Difficulty struct {
+	Score           int    `json:"score"`
+	Label           string `json:"label"`
+	SolverGuesses   int    `json:"solver_guesses,omitempty"`
+	ExpectedGuesses int    `json:"expected_guesses"`
+	// LimitTooLow warns that typical players will run out of guesses.
+	LimitTooLow bool `json:"limit_too_low"`
+}
+
+// FromDifficulty converts a game's difficulty for the API, or returns nil
+// if the game wasn't rated.
+func FromDifficulty(game *wording.Game) *Difficulty {
+	d := game.Difficulty
+	if d == nil {
+		return nil
+	}
+
+	return &Difficulty{
+		Score:           d.Score,
+		Label:           d.Label(),
+		SolverGuesses:   d.SolverGuesses,
+		ExpectedGuesses: d.ExpectedGuesses(),
+		LimitTooLow:     d.LimitTooLow(game.GuessLimit),
+	}
+}
+
+// Stats are totals along with a daily time series, oldest day first.
+type Stats struct {
+	GamesCreated int `json:"games_created,omitempty"`
+	GuessesMade  int `json:"guesses_made"`
+	GamesWon     int `json:"games_won"`
+	// Game is only set for a single game's stats.
+	Game *GameSummary `json:"game,omitempty"`
+	Days []Day        `json:"days"`
+}
+
+// GameSummary is how a game's players have done so far.
+type GameSummary struct {
+	PlayersStarted  int `json:"players_started"`
+	PlayersFinished int `json:"players_finished"`
+	Losses          int `json:"losses"`
+	// WinsIn counts wins by how many guesses they took, starting at one.
+	// It's as long as the guess limit.
+	WinsIn            []int       `json:"wins_in"`
+	FirstGuesses      []WordCount `json:"first_guesses"`
+	WrongFinalGuesses []WordCount `json:"wrong_final_guesses"`
+}
+
+// WordCount is how many times a word was guessed.
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Day is a single day's stats.
+type Day struct {
+	// Date is written as YYYY-MM-DD, in UTC.
+	Date           string `json:"date"`
+	GamesCreated   int    `json:"games_created,omitempty"`
+	PlayersStarted int    `json:"players_started"`
+	GuessesMade    int    `json:"guesses_made"`
+	GamesWon       int    `json:"games_won"`
+	// WinsIn counts wins by how many guesses they took, starting at one.
+	WinsIn []int `json:"wins_in"`
+}
+
+// DateLayout is how Day.Date is written.
+const DateLayout = "2006-01-02"
+
+// FromStats converts totals and their daily time series for the API.
+func FromStats(totals wording.Stats, days []wording.DailyStats) Stats {
+	stats := Stats{
+		GamesCreated: totals.GamesCreated,
+		GuessesMade:  totals.GuessesMade,
+		GamesWon:     totals.GamesWon,
+		Days:         make([]Day, len(days)),
+	}
+
+	for i, d := range days {
+		winsIn := d.WinsIn
+		if winsIn == nil {
+			winsIn = []int{}
+		}
+
+		stats.Days[i] = Day{
+			Date:           d.Day.Format(DateLayout),
+			GamesCreated:   d.GamesCreated,
+			PlayersStarted: d.PlayersStarted,
+			GuessesMade:    d.GuessesMade,
+			GamesWon:       d.GamesWon,
+			WinsIn:         winsIn,
+		}
+	}
+
+	return stats
+}
+
+// FromGameStats is FromStats for a single game.
+func FromGameStats(game wording.GameStats, days []wording.DailyStats) Stats {
+	stats := FromStats(game.Stats, days)
+	stats.Game = &GameSummary{
+		PlayersStarted:    game.PlayersStarted,
+		PlayersFinished:   game.PlayersFinished,
+		Losses:            game.Losses,
+		WinsIn:            game.WinsIn,
+		FirstGuesses:      fromWordCounts(game.FirstGuesses),
+		WrongFinalGuesses: fromWordCounts(game.WrongFinalGuesses),
+	}
+
+	return stats
+}
+
+func fromWordCounts(words []wording.WordCount) []WordCount {
+	counts := make([]WordCount, len(words))
+	for i, w := range words {
+		counts[i] = WordCount{Word: w.Word, Count: w.Count}
+	}
+	return counts
+}
+
+// PlayerStats sum up a player's history.
+type PlayerStats struct {
+	GamesPlayed   int `json:"games_played"`
+	GamesFinished int `json:"games_finished"`
+	GamesWon      int `json:"games_won"`
+	// WinRate is the percentage of finished games that were won.
+	WinRate       int `json:"win_rate"`
+	CurrentStreak int `json:"current_streak"`
+	MaxStreak     int `json:"max_streak"`
+	// WinsIn counts wins by how many guesses they took, starting at one.
+	WinsIn []int `json:"wins_in"`
+}
+
+// FromPlayerStats converts a player's stats for the API.
+func FromPlayerStats(s wording.PlayerStats) PlayerStats {
+	winsIn := s.WinsIn
+	if winsIn == nil {
+		winsIn = []int{}
+	}
+
+	return PlayerStats{
+		GamesPlayed:   s.GamesPlayed,
+		GamesFinished: s.GamesFinished,
+		GamesWon:      s.GamesWon,
+		WinRate:       s.WinRate(),
+		CurrentStreak: s.CurrentStreak,
+		MaxStreak:     s.MaxStreak,
+		WinsIn:        winsIn,
+	}
+}
+
+// PlayerHistory is every game a player has played, oldest first.
+type PlayerHistory struct {
+	Games []PlayedGame `json:"games"`
+}
+
+// PlayedGame is a game in a player's history.
+type PlayedGame struct {
+	Token      string    `json:"token"`
+	StartedAt  time.Time `json:"started_at"`
+	GuessLimit int       `json:"guess_limit"`
+	Guesses    []string  `json:"guesses"`
+	Won        bool      `json:"won"`
+	Finished   bool      `json:"finished"`
+	// Answer is only given for finished games.
+	Answer string `json:"answer,omitempty"`
+}
+
+// FromPlayerHistory converts a player's games for the API.
+func FromPlayerHistory(games []wording.PlayerGame) PlayerHistory {
+	h := PlayerHistory{Games: make([]PlayedGame, len(games))}
+	for i := range games {
+		g := &games[i]
+		guesses := g.Attempts
+		if guesses == nil {
+			guesses = []string{}
+		}
+
+		h.Games[i] = PlayedGame{
+			Token:      g.Token,
+			StartedAt:  g.StartedAt.UTC(),
+			GuessLimit: g.GuessLimit,
+			Guesses:    guesses,
+			Won:        g.Won(),
+			Finished:   g.Finished(),
+			Answer:     g.Answer,
+		}
+	}
+	return h
+}
+
+// ClearedHistory says how many games a player's guesses were forgotten in.
+type ClearedHistory struct {
+	Games int `json:"games"`
+}
+
+// GuessRequest submits a guess.
+type GuessRequest struct {
+	Guess string `json:"guess"`
+}
+
+// Error is the body of every unsuccessful response.
+type Error struct {
+	Status     int                 `json:"-"`
+	Message    string              `json:"error"`
+	Violations map[string][]string `json:"violations,omitempty"`
+}
+
+// Error describes the problem, including any input violations.
+func (e *Error) Error() string {
+	if len(e.Violations) > 0 {
+		return fmt.Sprintf("%s: %v", e.Message, e.Violations)
+	}
+	return e.Message
+}
+
+// IsStatus reports whether err is an API error with the given HTTP status.
+func IsStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
+
+// NewError builds an Error for status, using the status text as the message
+// if msg is empty.
+func NewError(status int, msg string) *Error {
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+	return &Error{Status: status, Message: msg}
+}
+
+// FromViolations builds a 400 Error from input violations.
+func FromViolations(v wording.InputViolations) *Error {
+	e := NewError(http.StatusBadRequest, "invalid input")
+	e.Violations = make(map[string][]string, len(v))
+	for field, errs := range v {
+		for _, err := range errs {
+			e.Violations[field] = append(e.Violations[field], err.Error())
+		}
+	}
+	return e
+}
+
+// FromGameState converts the game's state for the API.
+func FromGameState(s *wording.GameState) GameState {
+	state := GameState{
+		Attempts:    make([][]Character, 0, len(s.Attempts)),
+		CanContinue: s.CanContinue,
+		Victorious:  s.IsVictorious,
+		GameOver:    s.GameOver,
+		Answer:      s.Answer,
+	}
+
+	for _, attempt := range s.Attempts {
+		chars := make([]Character, 0, len(attempt))
+		for _, ch := range attempt {
+			chars = append(chars, Character{
+				Value:   ch.Value,
+				Correct: ch.IsCorrect,
+				Partial: ch.IsPartial,
+			})
+		}
+		state.Attempts = append(state.Attempts, chars)
+	}
+
+	return state
+}
+
+// GameState converts the state back into the game's own types.
+func (s GameState) GameState() *wording.GameState {
+	state := &wording.GameState{
+		CanContinue:  s.CanContinue,
+		IsVictorious: s.Victorious,
+		GameOver:     s.GameOver,
+		Answer:       s.Answer,
+	}
+
+	for _, chars := range s.Attempts {
+		attempt := make(wording.Attempt, 0, len(chars))
+		for _, ch := range chars {
+			attempt = append(attempt, wording.Character{
+				Value:     ch.Value,
+				IsCorrect: ch.Correct,
+				IsPartial: ch.Partial,
+			})
+		}
+		state.Attempts = append(state.Attempts, attempt)
+	}
+
+	return state
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client plays games through a wording server's API.
+type Client struct {
+	baseURL     string
+	http        *http.Client
+	playerToken string
+}
+
+// NewClient creates a client for the server at baseURL. playerToken is the
+// signed token from a previous session, or empty to be issued a new one.
+func NewClient(baseURL, playerToken string) *Client {
+	return &Client{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		http:        &http.Client{Timeout: 10 * time.Second},
+		playerToken: playerToken,
+	}
+}
+
+// PlayerToken returns the signed player token the server last issued, to be
+// saved and passed to NewClient next time.
+func (c *Client) PlayerToken() string {
+	return c.playerToken
+}
+
+// Game fetches the game and the player's progress.
+func (c *Client) Game(ctx context.Context, token string) (*Game, error) {
+	var game Game
+	err := c.do(ctx, http.MethodGet, "/api/games/"+url.PathEscape(token), nil, &game)
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// CreateGame creates a game.
+func (c *Client) CreateGame(ctx context.Context, answer string, guessLimit int) (*CreatedGame, error) {
+	var game CreatedGame
+	err := c.do(ctx, http.MethodPost, "/api/games", CreateGameRequest{Answer: answer, GuessLimit: guessLimit}, &game)
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// Guess submits a guess and returns the player's updated progress.
+func (c *Client) Guess(ctx context.Context, token, guess string) (*Game, error) {
+	var game Game
+	err := c.do(ctx, http.MethodPost, "/api/games/"+url.PathEscape(token)+"/guesses", GuessRequest{Guess: guess}, &game)
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// GameStats fetches a game's stats for the last days, given its admin
+// token.
+func (c *Client) GameStats(ctx context.Context, adminToken string, days int) (*Stats, error) {
+	var stats Stats
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/manage/%s/stats?days=%d", url.PathEscape(adminToken), days), nil, &stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.playerToken != "" {
+		req.Header.Set(PlayerHeader, c.playerToken)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if token := resp.Header.Get(PlayerHeader); token != "" {
+		c.playerToken = token
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := NewError(resp.StatusCode, "")
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+			_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		}
+		return apiErr
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("decoding %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,557 @@
+// Package config loads the application's configuration from a YAML file,
+// the environment and command line flags.
+//
+// Settings are applied in order of increasing precedence: built-in
+// defaults, then the config file, then WORDING_* environment variables,
+// then flags.
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/connorkuehl/wording/internal/ratelimit"
+	"github.com/connorkuehl/wording/internal/sealer"
+)
+
+// Config is the application's configuration.
+type Config struct {
+	Environment string  `yaml:"environment"`
+	BaseURL     string  `yaml:"base_url"`
+	DBDSN       string  `yaml:"db_dsn"`
+	BindAddr    string  `yaml:"bind_addr"`
+	MetricsAddr string  `yaml:"metrics_addr"`
+	WordGenSvc  string  `yaml:"word_gen_svc"`
+	CookieKeys  Secrets `yaml:"cookie_keys"`
+	// AnswerKeys encrypt answers in the database, newest first.
+	AnswerKeys Secrets `yaml:"answer_keys"`
+	// Words is a word list used to rate how hard answers are. Answers are
+	// rated by their letters alone without one.
+	Words string `yaml:"words"`
+
+	Log struct {
+		Level  string `yaml:"level"`
+		Format string `yaml:"format"`
+	} `yaml:"log"`
+
+	Limits struct {
+		CreateGameIP ratelimit.Rule `yaml:"create_game_ip"`
+		GuessIP      ratelimit.Rule `yaml:"guess_ip"`
+		GuessPlayer  ratelimit.Rule `yaml:"guess_player"`
+		GuessGame    ratelimit.Rule `yaml:"guess_game"`
+		LoginIP      ratelimit.Rule `yaml:"login_ip"`
+	} `yaml:"limits"`
+
+	Server struct {
+		ReadTimeout       time.Duration `yaml:"read_timeout"`
+		ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+		WriteTimeout      time.Duration `yaml:"write_timeout"`
+		IdleTimeout       time.Duration `yaml:"idle_timeout"`
+		ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+		ShutdownDelay     time.Duration `yaml:"shutdown_delay"`
+	} `yaml:"server"`
+
+	Health struct {
+		CheckTimeout time.Duration `yaml:"check_timeout"`
+	} `yaml:"health"`
+
+	Tracing struct {
+		Exporter     string  `yaml:"exporter"`
+		OTLPEndpoint string  `yaml:"otlp_endpoint"`
+		OTLPInsecure bool    `yaml:"otlp_insecure"`
+		SampleRatio  float64 `yaml:"sample_ratio"`
+	} `yaml:"tracing"`
+
+	Retention struct {
+		// IdleAfter is how long a game may go without being played or
+		// managed before it is pruned. Zero keeps games forever.
+		IdleAfter time.Duration `yaml:"idle_after"`
+		// PruneInterval is how often the server looks for idle games.
+		PruneInterval time.Duration `yaml:"prune_interval"`
+	} `yaml:"retention"`
+
+	Mail struct {
+		// Sender delivers login links: none, which turns logging in off,
+		// stdout, file or smtp. stdout and file are for development.
+		Sender       string `yaml:"sender"`
+		File         string `yaml:"file"`
+		From         string `yaml:"from"`
+		SMTPAddr     string `yaml:"smtp_addr"`
+		SMTPUsername string `yaml:"smtp_username"`
+		SMTPPassword string `yaml:"smtp_password"`
+	} `yaml:"mail"`
+
+	Admin struct {
+		// Auth lets operators into the admin console: none, which turns it
+		// off, basic, which asks for Username and Password, or stub, which
+		// lets everyone in as Username and is for development.
+		Auth     string `yaml:"auth"`
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+	} `yaml:"admin"`
+}
+
+// Default returns the built-in defaults.
+func Default() *Config {
+	var c Config
+
+	c.Environment = "dev"
+	c.MetricsAddr = "localhost:9090"
+
+	c.Log.Level = "info"
+	c.Log.Format = "text"
+
+	c.Limits.CreateGameIP = ratelimit.Rule{Requests: 30, Per: time.Hour}
+	c.Limits.GuessIP = ratelimit.Rule{Requests: 120, Per: time.Minute}
+	c.Limits.GuessPlayer = ratelimit.Rule{Requests: 30, Per: time.Minute}
+	c.Limits.GuessGame = ratelimit.Rule{Requests: 600, Per: time.Minute}
+	c.Limits.LoginIP = ratelimit.Rule{Requests: 10, Per: time.Hour}
+
+	c.Server.ReadTimeout = 10 * time.Second
+	c.Server.ReadHeaderTimeout = 5 * time.Second
+	c.Server.WriteTimeout = 15 * time.Second
+	c.Server.IdleTimeout = 60 * time.Second
+	c.Server.ShutdownTimeout = 25 * time.Second
+
+	c.Health.CheckTimeout = 2 * time.Second
+
+	c.Tracing.Exporter = "none"
+	c.Tracing.SampleRatio = 1
+
+	c.Retention.PruneInterval = time.Hour
+
+	c.Mail.Sender = "none"
+	c.Mail.From = "wording@localhost"
+
+	c.Admin.Auth = "none"
+	c.Admin.Username = "admin"
+
+	return &c
+}
+
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	value any
+}
+
+// settings lists every setting that can be changed from a flag or the
+// environment, bound to c's fields.
+func (c *Config) settings() []setting {
+	return []setting{
+		{"environment", "WORDING_ENVIRONMENT", "Environment (dev allows missing secrets)", &c.Environment},
+		{"base-url", "WORDING_BASE_URL", "Base URL to prefix links with", &c.BaseURL},
+		{"db-dsn", "WORDING_DB_DSN", "Postgres DSN", &c.DBDSN},
+		{"bind-addr", "WORDING_BIND_ADDR", "Bind address", &c.BindAddr},
+		{"metrics-addr", "WORDING_METRICS_ADDR", "Bind address for /metrics (not served if empty)", &c.MetricsAddr},
+		{"word-gen-svc", "WORDING_WORD_GEN_SVC", "Word generator API (game tokens are UUIDs if unset)", &c.WordGenSvc},
+		{"cookie-keys", "WORDING_COOKIE_KEYS", "Comma-separated secrets for signing cookies, newest first", &c.CookieKeys},
+		{"answer-keys", "WORDING_ANSWER_KEYS", "Comma-separated ID:BASE64 AES keys for encrypting answers, newest first", &c.AnswerKeys},
+		{"words", "WORDING_WORDS", "Word list for rating answers, one word per line with an optional usage count", &c.Words},
+		{"log-level", "WORDING_LOG_LEVEL", "Minimum level to log: debug, info, warn or error", &c.Log.Level},
+		{"log-format", "WORDING_LOG_FORMAT", "Log output format: text or json", &c.Log.Format},
+		{"limit-create-game-ip", "WORDING_LIMIT_CREATE_GAME_IP", "Game creation rate limit per IP (e.g., 30/h, or off)", &c.Limits.CreateGameIP},
+		{"limit-guess-ip", "WORDING_LIMIT_GUESS_IP", "Guess rate limit per IP", &c.Limits.GuessIP},
+		{"limit-guess-player", "WORDING_LIMIT_GUESS_PLAYER", "Guess rate limit per player", &c.Limits.GuessPlayer},
+		{"limit-guess-game", "WORDING_LIMIT_GUESS_GAME", "Guess rate limit per game", &c.Limits.GuessGame},
+		{"limit-login-ip", "WORDING_LIMIT_LOGIN_IP", "Login link rate limit per IP", &c.Limits.LoginIP},
+		{"read-timeout", "WORDING_READ_TIMEOUT", "Maximum duration for reading an entire request", &c.Server.ReadTimeout},
+		{"read-header-timeout", "WORDING_READ_HEADER_TIMEOUT", "Maximum duration for reading request headers", &c.Server.ReadHeaderTimeout},
+		{"write-timeout", "WORDING_WRITE_TIMEOUT", "Maximum duration before timing out writes of a response", &c.Server.WriteTimeout},
+		{"idle-timeout", "WORDING_IDLE_TIMEOUT", "Maximum time to wait for the next request on a keep-alive connection", &c.Server.IdleTimeout},
+		{"shutdown-timeout", "WORDING_SHUTDOWN_TIMEOUT", "Maximum time to wait for in-flight requests when shutting down", &c.Server.ShutdownTimeout},
+		{"shutdown-delay", "WORDING_SHUTDOWN_DELAY", "How long to keep serving while reporting unready before shutting down", &c.Server.ShutdownDelay},
+		{"health-check-timeout", "WORDING_HEALTH_CHECK_TIMEOUT", "Maximum time for each readiness check", &c.Health.CheckTimeout},
+		{"trace-exporter", "WORDING_TRACE_EXPORTER", "Where to send traces: none, stdout or otlp", &c.Tracing.Exporter},
+		{"otlp-endpoint", "WORDING_OTLP_ENDPOINT", "OTLP/HTTP collector host:port", &c.Tracing.OTLPEndpoint},
+		{"otlp-insecure", "WORDING_OTLP_INSECURE", "Disable TLS to the OTLP collector", &c.Tracing.OTLPInsecure},
+		{"trace-sample-ratio", "WORDING_TRACE_SAMPLE_RATIO", "Fraction of traces to sample", &c.Tracing.SampleRatio},
+		{"retention-idle-after", "WORDING_RETENTION_IDLE_AFTER", "Prune games idle for longer than this (0 keeps them forever)", &c.Retention.IdleAfter},
+		{"retention-prune-interval", "WORDING_RETENTION_PRUNE_INTERVAL", "How often to look for idle games", &c.Retention.PruneInterval},
+		{"mail-sender", "WORDING_MAIL_SENDER", "How to send login links: none (no logging in), stdout, file or smtp", &c.Mail.Sender},
+		{"mail-file", "WORDING_MAIL_FILE", "File to append mail to with the file sender", &c.Mail.File},
+		{"mail-from", "WORDING_MAIL_FROM", "Address mail is sent from", &c.Mail.From},
+		{"smtp-addr", "WORDING_SMTP_ADDR", "SMTP server host:port", &c.Mail.SMTPAddr},
+		{"smtp-username", "WORDING_SMTP_USERNAME", "SMTP username (no authentication if unset)", &c.Mail.SMTPUsername},
+		{"smtp-password", "WORDING_SMTP_PASSWORD", "SMTP password", &c.Mail.SMTPPassword},
+		{"admin-auth", "WORDING_ADMIN_AUTH", "How operators log in to /admin: none (no admin console), basic or stub (dev only)", &c.Admin.Auth},
+		{"admin-username", "WORDING_ADMIN_USERNAME", "Operator username", &c.Admin.Username},
+		{"admin-password", "WORDING_ADMIN_PASSWORD", "Operator password for basic auth", &c.Admin.Password},
+	}
+}
+
+type textValue interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+func (c *Config) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	for _, s := range c.settings() {
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+
+		switch v := s.value.(type) {
+		case *string:
+			fs.StringVar(v, s.flag, *v, usage)
+		case *bool:
+			fs.BoolVar(v, s.flag, *v, usage)
+		case *float64:
+			fs.Float64Var(v, s.flag, *v, usage)
+		case *time.Duration:
+			fs.DurationVar(v, s.flag, *v, usage)
+		case flag.Value:
+			fs.Var(v, s.flag, usage)
+		case textValue:
+			fs.TextVar(v, s.flag, v, usage)
+		default:
+			panic(fmt.Sprintf("config: unsupported setting type %T", v))
+		}
+	}
+
+	return fs
+}
+
+// Load builds the configuration from the defaults, the config file named by
+// -config or WORDING_CONFIG, the environment, and the flags in args. It
+// returns the arguments left over after the flags.
+func Load(name string, args []string, getenv func(string) string) (*Config, []string, error) {
+	c := Default()
+	fs := c.flagSet(name)
+
+	path := getenv("WORDING_CONFIG")
+	fs.StringVar(&path, "config", path, "Path to a YAML config file (env WORDING_CONFIG)")
+
+	// Flags are parsed first only to find the config file and to remember
+	// which flags were given, because they have to be applied last.
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	given := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = f.Value.String()
+	})
+
+	*c = *Default()
+
+	if path != "" {
+		err = c.loadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range c.settings() {
+		v := getenv(s.env)
+		if v == "" {
+			continue
+		}
+
+		err := fs.Set(s.flag, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", s.env, err)
+		}
+	}
+
+	for name, v := range given {
+		err := fs.Set(name, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("-%s: %w", name, err)
+		}
+	}
+
+	err = c.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c, fs.Args(), nil
+}
+
+func (c *Config) loadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+
+	err = dec.Decode(c)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Violations are all of the problems found while validating the
+// configuration.
+type Violations []string
+
+// Error lists every violation.
+func (v Violations) Error() string {
+	return "invalid configuration: " + strings.Join(v, "; ")
+}
+
+// require appends a violation to v if value is blank, and reports whether
+// it wasn't.
+func (v *Violations) require(name, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		*v = append(*v, name+" is required")
+		return false
+	}
+	return true
+}
+
+// err returns the violations sorted, or nil if there aren't any.
+func (v Violations) err() error {
+	if len(v) > 0 {
+		sort.Strings(v)
+		return v
+	}
+	return nil
+}
+
+// Validate checks that the settings every command needs are present and
+// that everything given is well-formed. Settings only the server needs are
+// checked by ValidateServe.
+func (c *Config) Validate() error {
+	var v Violations
+	require := v.require
+
+	require("environment", c.Environment)
+
+	if c.BaseURL != "" {
+		if err := validateURL(c.BaseURL); err != nil {
+			v = append(v, "base-url "+err.Error())
+		}
+	}
+
+	if c.WordGenSvc != "" {
+		if err := validateURL(c.WordGenSvc); err != nil {
+			v = append(v, "word-gen-svc "+err.Error())
+		}
+	}
+
+	require("db-dsn", c.DBDSN)
+
+	if len(c.AnswerKeys) == 0 && c.Environment != "dev" {
+		v = append(v, "answer-keys must be set outside of the dev environment")
+	}
+	for _, key := range c.AnswerKeys {
+		if _, err := sealer.ParseKey(key); err != nil {
+			v = append(v, "answer-keys: "+err.Error())
+		}
+	}
+
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		v = append(v, "log-format must be text or json")
+	}
+
+	switch c.Log.Level {
+	case "trace", "debug", "info", "warn", "warning", "error":
+	default:
+		v = append(v, "log-level must be one of debug, info, warn or error")
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		v = append(v, "trace-exporter must be none, stdout or otlp")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v = append(v, "trace-sample-ratio must be between 0 and 1")
+	}
+
+	for name, d := range map[string]time.Duration{
+		"read-timeout":         c.Server.ReadTimeout,
+		"read-header-timeout":  c.Server.ReadHeaderTimeout,
+		"write-timeout":        c.Server.WriteTimeout,
+		"idle-timeout":         c.Server.IdleTimeout,
+		"shutdown-timeout":     c.Server.ShutdownTimeout,
+		"shutdown-delay":       c.Server.ShutdownDelay,
+		"health-check-timeout": c.Health.CheckTimeout,
+		"retention-idle-after": c.Retention.IdleAfter,
+	} {
+		if d < 0 {
+			v = append(v, name+" must not be negative")
+		}
+	}
+
+	if c.Health.CheckTimeout == 0 {
+		v = append(v, "health-check-timeout must be positive")
+	}
+
+	if c.Retention.IdleAfter > 0 && c.Retention.PruneInterval <= 0 {
+		v = append(v, "retention-prune-interval must be positive when retention-idle-after is set")
+	}
+
+	switch c.Mail.Sender {
+	case "none", "stdout":
+	case "file":
+		require("mail-file", c.Mail.File)
+	case "smtp":
+		if require("smtp-addr", c.Mail.SMTPAddr) {
+			if _, _, err := net.SplitHostPort(c.Mail.SMTPAddr); err != nil {
+				v = append(v, "smtp-addr must be host:port")
+			}
+		}
+	default:
+		v = append(v, "mail-sender must be none, stdout, file or smtp")
+	}
+
+	if c.Mail.Sender != "none" {
+		require("mail-from", c.Mail.From)
+	}
+
+	switch c.Admin.Auth {
+	case "none":
+	case "basic":
+		require("admin-username", c.Admin.Username)
+		require("admin-password", c.Admin.Password)
+	case "stub":
+		require("admin-username", c.Admin.Username)
+		if c.Environment != "dev" {
+			v = append(v, "admin-auth stub is only allowed in the dev environment")
+		}
+	default:
+		v = append(v, "admin-auth must be none, basic or stub")
+	}
+
+	return v.err()
+}
+
+// ValidateServe checks the settings that only the server needs, on top of
+// what Validate checks.
+func (c *Config) ValidateServe() error {
+	var v Violations
+
+	v.require("base-url", c.BaseURL)
+
+	if v.require("bind-addr", c.BindAddr) {
+		if _, _, err := net.SplitHostPort(c.BindAddr); err != nil {
+			v = append(v, "bind-addr must be host:port")
+		}
+	}
+
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			v = append(v, "metrics-addr must be host:port")
+		} else if c.MetricsAddr == c.BindAddr {
+			v = append(v, "metrics-addr must differ from bind-addr")
+		}
+	}
+
+	if len(c.CookieKeys) == 0 && c.Environment != "dev" {
+		v = append(v, "cookie-keys must be set outside of the dev environment")
+	}
+
+	return v.err()
+}
+
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return errors.New("must be a valid URL")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("must be an http:// or https:// URL")
+	}
+
+	if u.Host == "" {
+		return errors.New("must include a host")
+	}
+
+	return nil
+}
+
+const redacted = "REDACTED"
+
+// Redacted returns the effective configuration as flag name/value pairs
+// with secrets hidden, suitable for logging.
+func (c *Config) Redacted() map[string]string {
+	cp := *c
+
+	if cp.DBDSN != "" {
+		if u, err := url.Parse(cp.DBDSN); err == nil && u.Scheme != "" {
+			cp.DBDSN = u.Redacted()
+		} else {
+			cp.DBDSN = redacted
+		}
+	}
+
+	cp.CookieKeys = cp.CookieKeys.redacted()
+	cp.AnswerKeys = cp.AnswerKeys.redacted()
+
+	if cp.Mail.SMTPPassword != "" {
+		cp.Mail.SMTPPassword = redacted
+	}
+
+	if cp.Admin.Password != "" {
+		cp.Admin.Password = redacted
+	}
+
+	m := make(map[string]string)
+	cp.flagSet("redacted").VisitAll(func(f *flag.Flag) {
+		m[f.Name] = f.Value.String()
+	})
+
+	return m
+}
+
+// Secrets is a list of secrets given as a comma-separated string.
+type Secrets []string
+
+// String joins the secrets with commas.
+func (s *Secrets) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set replaces the secrets with the comma-separated values in v.
+func (s *Secrets) Set(v string) error {
+	*s = nil
+	for _, secret := range strings.Split(v, ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			*s = append(*s, secret)
+		}
+	}
+	return nil
+}
+
+// redacted returns a copy with every secret hidden.
+func (s Secrets) redacted() Secrets {
+	r := make(Secrets, len(s))
+	for i := range r {
+		r[i] = redacted
+	}
+	return r
+}
+
+// Bytes returns each secret as a byte slice.
+func (s Secrets) Bytes() [][]byte {
+	b := make([][]byte, len(s))
+	for i := range s {
+		b[i] = []byte(s[i])
+	}
+	return b
+}
@@ -0,0 +1,233 @@
+// Package export reads and writes games, their plays and stats as JSON
+// Lines.
+//
+// Every line is a self-contained record tagged with the format version and
+// its kind, so exports can be streamed, concatenated and split by game:
+//
+//	{"version":1,"kind":"game","game":{"admin_token":"...","plays":[...]}}
+//	{"version":1,"kind":"stats","stats":{"games_created":3,...}}
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// Version is the format version that is written. Readers accept any
+// version up to it.
+const Version = 1
+
+// ContentType is the media type of an export.
+const ContentType = "application/jsonl"
+
+// ErrUnsupported means a record was read but is of a version or kind this
+// build doesn't understand. Reading can carry on past it.
+var ErrUnsupported = errors.New("unsupported record")
+
+// Kinds of records.
+const (
+	KindGame  = "game"
+	KindStats = "stats"
+)
+
+type line struct {
+	Version int    `json:"version"`
+	Kind    string `json:"kind"`
+	Game    *game  `json:"game,omitempty"`
+	Stats   *stats `json:"stats,omitempty"`
+}
+
+type game struct {
+	AdminToken string      `json:"admin_token"`
+	Token      string      `json:"token"`
+	Answer     string      `json:"answer"`
+	GuessLimit int         `json:"guess_limit"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Difficulty *difficulty `json:"difficulty,omitempty"`
+	// Creator is the player token of whoever created the game, so that it
+	// stays on their list of created games.
+	Creator string `json:"creator,omitempty"`
+	// RecoveryEmailHash is only the hash, so exports don't hold addresses.
+	RecoveryEmailHash string `json:"recovery_email_hash,omitempty"`
+	// OpensAt and ClosesAt are only set if the game is scheduled.
+	OpensAt  *time.Time `json:"opens_at,omitempty"`
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// Reveal is when players who didn't win are shown the answer. Games
+	// exported before it was added reveal it on loss.
+	Reveal string `json:"reveal,omitempty"`
+	// Disabled is set if an operator stopped the game from being played.
+	Disabled bool    `json:"disabled,omitempty"`
+	Plays    []plays `json:"plays"`
+}
+
+type difficulty struct {
+	Score         int `json:"score"`
+	SolverGuesses int `json:"solver_guesses,omitempty"`
+}
+
+type plays struct {
+	PlayerToken string    `json:"player_token"`
+	CreatedAt   time.Time `json:"created_at"`
+	Guesses     []string  `json:"guesses"`
+}
+
+type stats struct {
+	GamesCreated int `json:"games_created"`
+	GamesWon     int `json:"games_won"`
+	GuessesMade  int `json:"guesses_made"`
+}
+
+// Writer writes records as JSON Lines.
+type Writer struct {
+	enc *json.Encoder
+}
+
+// NewWriter creates a writer to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// WriteGame writes a game and its plays.
+func (w *Writer) WriteGame(r *wording.GameRecord) error {
+	g := &game{
+		AdminToken: r.AdminToken,
+		Token:      r.Token,
+		Answer:     r.Answer,
+		GuessLimit: r.GuessLimit,
+		CreatedAt:  r.CreatedAt,
+		Creator:    r.Creator,
+		Reveal:     string(r.Reveal),
+		Disabled:   r.Disabled,
+		Plays:      make([]plays, 0, len(r.Players)),
+
+		RecoveryEmailHash: r.RecoveryEmailHash,
+	}
+
+	if d := r.Difficulty; d != nil {
+		g.Difficulty = &difficulty{Score: d.Score, SolverGuesses: d.SolverGuesses}
+	}
+
+	if !r.OpensAt.IsZero() {
+		g.OpensAt = &r.OpensAt
+	}
+	if !r.ClosesAt.IsZero() {
+		g.ClosesAt = &r.ClosesAt
+	}
+
+	for _, p := range r.Players {
+		g.Plays = append(g.Plays, plays{
+			PlayerToken: p.PlayerToken,
+			CreatedAt:   p.CreatedAt,
+			Guesses:     p.Attempts,
+		})
+	}
+
+	return w.enc.Encode(line{Version: Version, Kind: KindGame, Game: g})
+}
+
+// WriteStats writes the lifetime stats.
+func (w *Writer) WriteStats(s wording.Stats) error {
+	return w.enc.Encode(line{Version: Version, Kind: KindStats, Stats: &stats{
+		GamesCreated: s.GamesCreated,
+		GamesWon:     s.GamesWon,
+		GuessesMade:  s.GuessesMade,
+	}})
+}
+
+// Record is a record that was read. Exactly one of Game and Stats is set.
+type Record struct {
+	// Number counts records from 1, for error messages.
+	Number int
+	Game   *wording.GameRecord
+	Stats  *wording.Stats
+}
+
+// Reader reads records written by Writer.
+type Reader struct {
+	dec *json.Decoder
+	n   int
+}
+
+// NewReader creates a reader from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{dec: json.NewDecoder(r)}
+}
+
+// Next reads the next record. It returns io.EOF when there are no more.
+func (r *Reader) Next() (*Record, error) {
+	var l line
+	err := r.dec.Decode(&l)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	r.n++
+	if err != nil {
+		return nil, fmt.Errorf("record %d: %w", r.n, err)
+	}
+
+	if l.Version < 1 || l.Version > Version {
+		return nil, fmt.Errorf("record %d: %w: version %d (this build reads up to %d)", r.n, ErrUnsupported, l.Version, Version)
+	}
+
+	rec := &Record{Number: r.n}
+
+	switch {
+	case l.Kind == KindGame && l.Game != nil:
+		rec.Game = l.Game.record()
+	case l.Kind == KindStats && l.Stats != nil:
+		rec.Stats = &wording.Stats{
+			GamesCreated: l.Stats.GamesCreated,
+			GamesWon:     l.Stats.GamesWon,
+			GuessesMade:  l.Stats.GuessesMade,
+		}
+	default:
+		return nil, fmt.Errorf("record %d: %w: unknown or empty %q record", r.n, ErrUnsupported, l.Kind)
+	}
+
+	return rec, nil
+}
+
+func (g *game) record() *wording.GameRecord {
+	r := &wording.GameRecord{
+		Game: wording.Game{
+			AdminToken: g.AdminToken,
+			Token:      g.Token,
+			Answer:     g.Answer,
+			GuessLimit: g.GuessLimit,
+			Disabled:   g.Disabled,
+			GameSettings: wording.GameSettings{
+				Reveal: wording.Reveal(g.Reveal),
+			},
+		},
+		CreatedAt: g.CreatedAt,
+		Creator:   g.Creator,
+
+		RecoveryEmailHash: g.RecoveryEmailHash,
+	}
+
+	if d := g.Difficulty; d != nil {
+		r.Difficulty = &wording.Difficulty{Score: d.Score, SolverGuesses: d.SolverGuesses}
+	}
+
+	if g.OpensAt != nil {
+		r.OpensAt = *g.OpensAt
+	}
+	if g.ClosesAt != nil {
+		r.ClosesAt = *g.ClosesAt
+	}
+
+	for _, p := range g.Plays {
+		r.Players = append(r.Players, wording.PlayerRecord{
+			PlayerToken: p.PlayerToken,
+			CreatedAt:   p.CreatedAt,
+			Plays:       wording.Plays{Attempts: p.Guesses},
+		})
+	}
+
+	return r
+}
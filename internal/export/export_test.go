@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2022, 11, 5, 12, 0, 0, 0, time.UTC)
+
+	game := &wording.GameRecord{
+		Game: wording.Game{
+			AdminToken: "wretched-apostle",
+			Token:      "hungry-hippo",
+			Answer:     "potato",
+			GuessLimit: 6,
+			Difficulty: &wording.Difficulty{Score: 42, SolverGuesses: 3},
+			Disabled:   true,
+			GameSettings: wording.GameSettings{
+				OpensAt:  created.Add(24 * time.Hour),
+				ClosesAt: created.Add(7 * 24 * time.Hour),
+				Reveal:   wording.RevealNever,
+			},
+		},
+		CreatedAt: created,
+		Creator:   "creator-one",
+
+		RecoveryEmailHash: "c0ffee",
+		Players: []wording.PlayerRecord{
+			{
+				PlayerToken: "player-one",
+				CreatedAt:   created.Add(time.Hour),
+				Plays:       wording.Plays{Attempts: []string{"tomato", "potato"}},
+			},
+		},
+	}
+	stats := wording.Stats{GamesCreated: 1, GamesWon: 1, GuessesMade: 2}
+
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	assert.NilError(t, w.WriteGame(game))
+	assert.NilError(t, w.WriteStats(stats))
+
+	assert.Equal(t, 2, strings.Count(b.String(), "\n"))
+
+	r := NewReader(&b)
+
+	rec, err := r.Next()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &Record{Number: 1, Game: game}, rec)
+
+	rec, err = r.Next()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &Record{Number: 2, Stats: &stats}, rec)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReadOlderGame(t *testing.T) {
+	input := `{"version":1,"kind":"game","game":{"admin_token":"wretched-apostle","token":"hungry-hippo","answer":"potato","guess_limit":6,"plays":[]}}`
+
+	rec, err := NewReader(strings.NewReader(input)).Next()
+	assert.NilError(t, err)
+	assert.Assert(t, !rec.Game.Disabled)
+	assert.DeepEqual(t, wording.GameSettings{}, rec.Game.GameSettings)
+}
+
+func TestReaderRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        string
+		unsupported bool
+	}{
+		{
+			name:        "newer version",
+			input:       `{"version":2,"kind":"game","game":{}}`,
+			want:        "version 2",
+			unsupported: true,
+		},
+		{
+			name:        "unknown kind",
+			input:       `{"version":1,"kind":"player"}`,
+			want:        `unknown or empty "player" record`,
+			unsupported: true,
+		},
+		{
+			name:  "not JSON",
+			input: `potato`,
+			want:  "record 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tt.input)).Next()
+			assert.ErrorContains(t, err, tt.want)
+			assert.Equal(t, tt.unsupported, errors.Is(err, ErrUnsupported))
+		})
+	}
+}
@@ -1,26 +1,42 @@
 package generator
 
 import (
-	"github.com/connorkuehl/wording/internal/randword"
+	"context"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/randword"
 )
 
 // Tokener produces a token.
 type Tokener interface {
-	NewToken() string
+	NewToken(ctx context.Context) string
 }
 
 // FallibleTokener tries to produce a token.
 type FallibleTokener interface {
-	NewToken() (string, error)
+	NewToken(ctx context.Context) (string, error)
 }
 
+// StatusTTL is how long a failure to use the fallible tokener is reported
+// by Status. Tokens are only made when games are created, so without it a
+// single failure would be reported until the next game is, long after the
+// dependency has recovered.
+const StatusTTL = 5 * time.Minute
+
 // FallibleGenerator tries to produce a token with a mechanism that
 // might fail, and if so, it will fallback to an infallible mechanism.
 type FallibleGenerator struct {
 	try      FallibleTokener
 	fallback Tokener
+	now      func() time.Time
+
+	mu        sync.Mutex
+	lastErr   error
+	lastErrAt time.Time
 }
 
 // NewFallibleGenerator creates a new FallibleGenerator.
@@ -28,20 +44,40 @@ func NewFallibleGenerator(try FallibleTokener, fallback Tokener) *FallibleGenera
 	return &FallibleGenerator{
 		try:      try,
 		fallback: fallback,
+		now:      time.Now,
 	}
 }
 
 // NewToken creates a token. If the fallible tokener fails, the
 // fallback is used instead.
-func (g *FallibleGenerator) NewToken() string {
-	tok, err := g.try.NewToken()
+func (g *FallibleGenerator) NewToken(ctx context.Context) string {
+	tok, err := g.try.NewToken(ctx)
+
+	g.mu.Lock()
+	g.lastErr = err
+	g.lastErrAt = g.now()
+	g.mu.Unlock()
+
 	if err != nil {
-		log.WithError(err).Warn("using fallback token generator")
-		tok = g.fallback.NewToken()
+		logging.From(ctx, log.StandardLogger()).WithError(err).Warn("using fallback token generator")
+		tok = g.fallback.NewToken(ctx)
 	}
 	return tok
 }
 
+// Status reports the error from the most recent attempt to use the
+// fallible tokener, or nil if it succeeded or failed more than StatusTTL
+// ago. It has the signature of a health check.
+func (g *FallibleGenerator) Status(context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.now().Sub(g.lastErrAt) >= StatusTTL {
+		return nil
+	}
+	return g.lastErr
+}
+
 // HumanReadable returns a URL slug full of human readable words.
 type HumanReadable struct {
 	client *randword.Client
@@ -55,6 +91,6 @@ func NewHumanReadable(client *randword.Client) *HumanReadable {
 }
 
 // NewToken tries to create a human readable slug.
-func (g *HumanReadable) NewToken() (string, error) {
-	return g.client.HumanReadableSlug()
+func (g *HumanReadable) NewToken(ctx context.Context) (string, error) {
+	return g.client.HumanReadableSlug(ctx)
 }
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type stubTokener struct {
+	err error
+}
+
+func (t *stubTokener) NewToken(context.Context) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	return "hungry-hippo", nil
+}
+
+type constTokener string
+
+func (t constTokener) NewToken(context.Context) string {
+	return string(t)
+}
+
+func TestFallibleGeneratorStatus(t *testing.T) {
+	ctx := context.Background()
+	errDown := errors.New("randword is down")
+
+	try := &stubTokener{err: errDown}
+	g := NewFallibleGenerator(try, constTokener("fallback"))
+
+	now := time.Unix(0, 0)
+	g.now = func() time.Time { return now }
+
+	assert.NilError(t, g.Status(ctx))
+
+	assert.Equal(t, "fallback", g.NewToken(ctx))
+	assert.Equal(t, errDown, g.Status(ctx))
+
+	now = now.Add(StatusTTL - time.Second)
+	assert.Equal(t, errDown, g.Status(ctx))
+
+	now = now.Add(time.Second)
+	assert.NilError(t, g.Status(ctx))
+
+	assert.Equal(t, "fallback", g.NewToken(ctx))
+	try.err = nil
+	assert.Equal(t, "hungry-hippo", g.NewToken(ctx))
+	assert.NilError(t, g.Status(ctx))
+}
@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
@@ -13,6 +15,6 @@ func NewUUIDGenerator() *UUIDGenerator {
 }
 
 // NewToken creates a UUID token.
-func (g *UUIDGenerator) NewToken() string {
+func (g *UUIDGenerator) NewToken(context.Context) string {
 	return uuid.NewString()
 }
@@ -0,0 +1,150 @@
+// Package health reports whether the application is alive and ready to
+// serve traffic.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports the health of a dependency by returning an error when it
+// is unhealthy.
+type Check func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	check    Check
+}
+
+// Status is the outcome of a single dependency check.
+type Status struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	Latency   string  `json:"latency"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the body of a health response.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Status `json:"checks,omitempty"`
+}
+
+const (
+	statusOK           = "ok"
+	statusFailing      = "failing"
+	statusUnavailable  = "unavailable"
+	statusShuttingDown = "shutting down"
+)
+
+// Checker serves the liveness and readiness endpoints.
+type Checker struct {
+	timeout      time.Duration
+	checks       []check
+	shuttingDown atomic.Bool
+}
+
+// New creates a Checker whose readiness checks are each given at most
+// timeout to complete.
+func New(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a readiness check. A failing critical check makes the
+// application unready; a failing non-critical check is only reported,
+// which suits dependencies that have a fallback.
+func (c *Checker) Register(name string, critical bool, fn Check) {
+	c.checks = append(c.checks, check{name: name, critical: critical, check: fn})
+}
+
+// ShutDown marks the application as unready so that load balancers stop
+// sending it new traffic while in-flight requests drain.
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Live reports that the process is up and able to serve HTTP. It does not
+// look at dependencies: restarting the process won't fix a database outage.
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: statusOK})
+}
+
+// Ready reports whether the application can serve traffic by running every
+// registered check concurrently.
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+
+	code := http.StatusOK
+	if report.Status != statusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	writeReport(w, code, report)
+}
+
+// Check runs every registered check and summarizes the results.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status: statusOK,
+		Checks: make(map[string]Status, len(c.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, chk := range c.checks {
+		chk := chk
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := chk.check(ctx)
+			latency := time.Since(start)
+
+			status := Status{
+				Status:    statusOK,
+				Critical:  chk.critical,
+				Latency:   latency.String(),
+				LatencyMS: float64(latency.Microseconds()) / 1000,
+			}
+			if err != nil {
+				status.Status = statusFailing
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[chk.name] = status
+			if err != nil && chk.critical {
+				report.Status = statusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.shuttingDown.Load() {
+		report.Status = statusShuttingDown
+	}
+
+	return report
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func ready(t *testing.T, c *Checker) (int, Report) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c.Ready(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	var report Report
+	assert.NilError(t, json.NewDecoder(w.Body).Decode(&report))
+	return w.Code, report
+}
+
+func TestReady(t *testing.T) {
+	healthy := func(context.Context) error { return nil }
+	broken := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		postgres   Check
+		randword   Check
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "all healthy", postgres: healthy, randword: healthy, wantCode: http.StatusOK, wantStatus: "ok"},
+		{name: "critical check failing", postgres: broken, randword: healthy, wantCode: http.StatusServiceUnavailable, wantStatus: "unavailable"},
+		{name: "non-critical check failing", postgres: healthy, randword: broken, wantCode: http.StatusOK, wantStatus: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(time.Second)
+			c.Register("postgres", true, tt.postgres)
+			c.Register("randword", false, tt.randword)
+
+			code, report := ready(t, c)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, 2, len(report.Checks))
+			assert.Equal(t, true, report.Checks["postgres"].Critical)
+		})
+	}
+}
+
+func TestReadyReportsErrors(t *testing.T) {
+	c := New(time.Second)
+	c.Register("postgres", true, func(context.Context) error { return errors.New("connection refused") })
+
+	_, report := ready(t, c)
+	assert.Equal(t, "failing", report.Checks["postgres"].Status)
+	assert.Equal(t, "connection refused", report.Checks["postgres"].Error)
+}
+
+func TestReadyTimesOutChecks(t *testing.T) {
+	c := New(10 * time.Millisecond)
+	c.Register("postgres", true, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, _ := ready(t, c)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestShutDown(t *testing.T) {
+	c := New(time.Second)
+	c.Register("postgres", true, func(context.Context) error { return nil })
+
+	c.ShutDown()
+
+	code, report := ready(t, c)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "shutting down", report.Status)
+
+	w := httptest.NewRecorder()
+	c.Live(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Middleware attaches the request ID to the request's log fields and logs a
+// summary of every request once it has been handled. It must run after
+// chi's RequestID middleware.
+//
+// The summary names the route pattern rather than the path, since paths
+// such as /manage/{admin_token} and /login/{token} contain secrets.
+func Middleware(logger logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			ctx := AddFields(r.Context(), logrus.Fields{
+				"request_id": middleware.GetReqID(r.Context()),
+			})
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			f := logrus.Fields{
+				"method":   r.Method,
+				"status":   status,
+				"bytes":    ww.BytesWritten(),
+				"duration": time.Since(start).String(),
+				"remote":   r.RemoteAddr,
+			}
+			if rctx := chi.RouteContext(ctx); rctx != nil {
+				f["route"] = rctx.RoutePattern()
+			}
+
+			From(ctx, logger).WithFields(f).Info("handled request")
+		})
+	}
+}
+
+// Game attaches the route's {token} URL parameter to the request's log
+// fields as the game token, so that every line logged while handling the
+// request carries it. It belongs on routes whose {token} is a game token,
+// not on those where it's a login or recovery token.
+func Game(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if token := chi.URLParam(r, "token"); token != "" {
+			ctx = AddFields(ctx, logrus.Fields{"game": token})
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
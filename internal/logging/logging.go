@@ -0,0 +1,92 @@
+// Package logging configures structured logging and carries per-request
+// fields, such as the request ID, through the context so that every line
+// logged while handling a request can be correlated.
+package logging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Formats that Configure understands.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Configure sets the logger's output format and level.
+func Configure(logger *logrus.Logger, out io.Writer, level, format string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatText:
+		logger.SetFormatter(&logrus.TextFormatter{})
+	case FormatJSON:
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	logger.SetOutput(out)
+	logger.SetLevel(lvl)
+
+	return nil
+}
+
+type fieldsKey struct{}
+
+// fields is shared by everything handling the same request, so that fields
+// discovered deep inside a handler (e.g., the player) still show up on the
+// request's summary line logged by the outermost middleware.
+type fields struct {
+	mu     sync.Mutex
+	fields logrus.Fields
+}
+
+// AddFields attaches fields to every line logged through From for the rest
+// of the request.
+func AddFields(ctx context.Context, f logrus.Fields) context.Context {
+	bag, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		bag = &fields{fields: make(logrus.Fields)}
+		ctx = context.WithValue(ctx, fieldsKey{}, bag)
+	}
+
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+
+	for k, v := range f {
+		bag.fields[k] = v
+	}
+
+	return ctx
+}
+
+// From returns a logger that includes the fields attached to ctx.
+func From(ctx context.Context, logger logrus.FieldLogger) logrus.FieldLogger {
+	bag, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return logger
+	}
+
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+
+	return logger.WithFields(bag.fields)
+}
+
+// HashToken obscures a secret token (e.g., a player token) so that log lines
+// can be correlated without leaking something that grants access.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:6])
+}
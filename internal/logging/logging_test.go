@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"gotest.tools/assert"
+)
+
+func TestFrom(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+
+	From(context.Background(), logger).Info("no fields")
+	assert.Equal(t, 0, len(hook.LastEntry().Data))
+
+	ctx := AddFields(context.Background(), logrus.Fields{"request_id": "abc"})
+	AddFields(ctx, logrus.Fields{"player": "123"})
+
+	From(ctx, logger).Info("fields")
+	assert.DeepEqual(t, logrus.Fields{"request_id": "abc", "player": "123"}, hook.LastEntry().Data)
+}
+
+func TestMiddleware(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(Middleware(logger))
+	router.With(Game).Get("/game/{token}", func(w http.ResponseWriter, r *http.Request) {
+		AddFields(r.Context(), logrus.Fields{"player": HashToken("player")})
+		From(r.Context(), logger).Info("inside handler")
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/game/hungry-hippo", nil))
+
+	entries := hook.AllEntries()
+	assert.Equal(t, 2, len(entries))
+
+	inside, summary := entries[0], entries[1]
+	assert.Assert(t, inside.Data["request_id"] != "")
+	assert.Equal(t, "hungry-hippo", inside.Data["game"])
+	assert.Equal(t, inside.Data["request_id"], summary.Data["request_id"])
+	assert.Equal(t, HashToken("player"), summary.Data["player"])
+	assert.Equal(t, "hungry-hippo", summary.Data["game"])
+	assert.Equal(t, "/game/{token}", summary.Data["route"])
+	assert.Equal(t, http.StatusTeapot, summary.Data["status"])
+}
+
+func TestMiddlewareOmitsSecretPaths(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(Middleware(logger))
+	router.Get("/manage/{admin_token}", func(w http.ResponseWriter, r *http.Request) {})
+	router.Get("/login/{token}", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"/manage/admin-secret", "/login/login-secret"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	entries := hook.AllEntries()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "/manage/{admin_token}", entries[0].Data["route"])
+	assert.Equal(t, "/login/{token}", entries[1].Data["route"])
+	for _, entry := range entries {
+		for k, v := range entry.Data {
+			s, _ := v.(string)
+			assert.Assert(t, !strings.Contains(s, "secret"), "%s: %v", k, v)
+		}
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	assert.Equal(t, HashToken("player"), HashToken("player"))
+	assert.Assert(t, HashToken("player") != HashToken("other-player"))
+	assert.Equal(t, 12, len(HashToken("player")))
+}
+
+func TestRecentErrors(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	recent := NewRecentErrors(2)
+	logger.AddHook(recent)
+
+	logger.Warn("not an error")
+	assert.Equal(t, 0, len(recent.Recent()))
+
+	logger.WithField("game", "hungry-hippo").WithField("attempt", 1).Error("first")
+	logger.Error("second")
+	logger.Error("third")
+
+	got := recent.Recent()
+	assert.Equal(t, 2, len(got))
+	assert.Equal(t, "third", got[0].Message)
+	assert.Equal(t, "second", got[1].Message)
+
+	recent = NewRecentErrors(2)
+	logger.AddHook(recent)
+	logger.WithField("game", "hungry-hippo").WithField("attempt", 1).Error("first")
+	assert.Equal(t, "attempt=1 game=hungry-hippo", recent.Recent()[0].Fields)
+}
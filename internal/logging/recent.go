@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LoggedError is an error line kept by RecentErrors.
+type LoggedError struct {
+	Time    time.Time
+	Message string
+	// Fields are the line's fields as key=value pairs, sorted by key.
+	Fields string
+}
+
+// RecentErrors is a logrus hook that keeps the last few lines logged at
+// error level or worse, so that operators can see what has gone wrong
+// without access to the logs. Each replica only keeps its own.
+type RecentErrors struct {
+	mu     sync.Mutex
+	errors []LoggedError
+	next   int
+}
+
+// NewRecentErrors creates a hook that keeps the last size errors.
+func NewRecentErrors(size int) *RecentErrors {
+	return &RecentErrors{errors: make([]LoggedError, 0, size)}
+}
+
+// Levels returns the levels kept.
+func (r *RecentErrors) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire keeps the line, replacing the oldest if there are already enough.
+func (r *RecentErrors) Fire(entry *logrus.Entry) error {
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, len(keys))
+	for i, k := range keys {
+		fields[i] = fmt.Sprintf("%s=%v", k, entry.Data[k])
+	}
+
+	e := LoggedError{
+		Time:    entry.Time,
+		Message: entry.Message,
+		Fields:  strings.Join(fields, " "),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.errors) < cap(r.errors) {
+		r.errors = append(r.errors, e)
+		return nil
+	}
+
+	if len(r.errors) > 0 {
+		r.errors[r.next] = e
+		r.next = (r.next + 1) % len(r.errors)
+	}
+
+	return nil
+}
+
+// Recent returns the errors kept, newest first.
+func (r *RecentErrors) Recent() []LoggedError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recent := make([]LoggedError, len(r.errors))
+	for i := range recent {
+		// next is the oldest once the buffer is full, and zero until then.
+		recent[i] = r.errors[(r.next+len(r.errors)-1-i)%len(r.errors)]
+	}
+
+	return recent
+}
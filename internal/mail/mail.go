@@ -0,0 +1,165 @@
+// Package mail sends email, such as the links players log in with.
+//
+// Mail goes out over SMTP in production. During development a Writer can
+// print messages to the terminal or append them to a file instead.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ErrInvalidHeader means a recipient or subject contains a line break,
+// which could be used to inject headers.
+var ErrInvalidHeader = errors.New("mail: header contains a line break")
+
+// format renders msg as an RFC 5322 message.
+func format(from string, msg Message, date time.Time) ([]byte, error) {
+	for _, h := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(h, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return b.Bytes(), nil
+}
+
+// SMTP sends mail through an SMTP server.
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTP creates a sender that relays through the server at addr
+// (host:port). It authenticates with PLAIN auth if a username is given,
+// which net/smtp only allows over TLS or to localhost.
+func NewSMTP(addr, from, username, password string) *SMTP {
+	s := &SMTP{addr: addr, from: from}
+
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s
+}
+
+// Send sends msg. net/smtp doesn't take a context, so ctx is only checked
+// before connecting.
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	b, err := format(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, b)
+}
+
+// Writer writes messages to an io.Writer, one after another, instead of
+// sending them. It's meant for development.
+type Writer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+// NewWriter creates a sender that writes to w.
+func NewWriter(w io.Writer, from string) *Writer {
+	return &Writer{w: w, from: from}
+}
+
+// Send writes msg followed by a blank line.
+func (s *Writer) Send(ctx context.Context, msg Message) error {
+	b, err := format(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(b, "\r\n\r\n"...))
+	return err
+}
+
+// Links emails players the links they log in or manage their games with.
+type Links struct {
+	sender  Sender
+	baseURL string
+}
+
+// NewLinks creates a mailer for links that point at baseURL.
+func NewLinks(sender Sender, baseURL string) *Links {
+	return &Links{sender: sender, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// SendLoginLink emails a link that logs in with token.
+func (l *Links) SendLoginLink(ctx context.Context, to, token string, expiresIn time.Duration) error {
+	return l.sender.Send(ctx, Message{
+		To:      to,
+		Subject: "Your wording login link",
+		Body: fmt.Sprintf(`Follow this link to log in to wording:
+
+%s/login/%s
+
+It works once and expires in %d minutes. If you didn't ask to log in, you can
+ignore this email.
+`, l.baseURL, token, int(expiresIn.Minutes())),
+	})
+}
+
+// SendRecoveryLink emails a link that replaces the admin links of the
+// games recoverable with the address, using token.
+func (l *Links) SendRecoveryLink(ctx context.Context, to, token string, expiresIn time.Duration) error {
+	return l.sender.Send(ctx, Message{
+		To:      to,
+		Subject: "Recover your wording games",
+		Body: fmt.Sprintf(`Follow this link to get new admin links for the wording games with this
+recovery email:
+
+%s/recover/%s
+
+The old admin links will stop working once you do. It works once and
+expires in %d minutes. If you didn't ask for this, you can ignore this email
+and nothing will change.
+`, l.baseURL, token, int(expiresIn.Minutes())),
+	})
+}
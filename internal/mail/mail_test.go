@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestSendLoginLink(t *testing.T) {
+	var b bytes.Buffer
+	links := NewLinks(NewWriter(&b, "wording@example.com"), "https://wording.example/")
+
+	err := links.SendLoginLink(context.Background(), "player@example.com", "s3cret", 15*time.Minute)
+	assert.NilError(t, err)
+
+	msg := b.String()
+	assert.Assert(t, strings.HasPrefix(msg, "From: wording@example.com\r\nTo: player@example.com\r\n"), msg)
+	assert.Assert(t, strings.Contains(msg, "\r\nhttps://wording.example/login/s3cret\r\n"), msg)
+	assert.Assert(t, strings.Contains(msg, "expires in 15 minutes"), msg)
+}
+
+func TestWriterRejectsHeaderInjection(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, "wording@example.com")
+
+	err := w.Send(context.Background(), Message{To: "player@example.com\r\nBcc: everyone@example.com", Subject: "hi"})
+	assert.Equal(t, ErrInvalidHeader, err)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestSendRecoveryLink(t *testing.T) {
+	var b bytes.Buffer
+	links := NewLinks(NewWriter(&b, "wording@example.com"), "https://wording.example")
+
+	err := links.SendRecoveryLink(context.Background(), "player@example.com", "s3cret", 15*time.Minute)
+	assert.NilError(t, err)
+
+	msg := b.String()
+	assert.Assert(t, strings.Contains(msg, "\r\nhttps://wording.example/recover/s3cret\r\n"), msg)
+	assert.Assert(t, strings.Contains(msg, "expires in 15 minutes"), msg)
+}
@@ -0,0 +1,6 @@
+// Package metrics instruments the application for Prometheus.
+//
+// The business logic stays oblivious to metrics: the service, store and
+// token generators are wrapped in decorators that record what passes
+// through them.
+package metrics
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/connorkuehl/wording/internal/generator"
+)
+
+type instrumentedTokener struct {
+	m   *Metrics
+	try generator.FallibleTokener
+}
+
+// FallibleTokener decorates the fallible half of a FallibleGenerator so that
+// every failure, and therefore every use of the fallback, is counted.
+func (m *Metrics) FallibleTokener(try generator.FallibleTokener) generator.FallibleTokener {
+	return &instrumentedTokener{m: m, try: try}
+}
+
+func (t *instrumentedTokener) NewToken(ctx context.Context) (string, error) {
+	tok, err := t.try.NewToken(ctx)
+	if err != nil {
+		t.m.tokenFallbacks.Inc()
+	}
+	return tok, err
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "wording"
+
+// Metrics holds the application's collectors.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests   *prometheus.CounterVec
+	httpDuration   *prometheus.HistogramVec
+	serviceCalls   *prometheus.CounterVec
+	serviceLatency *prometheus.HistogramVec
+	storeLatency   *prometheus.HistogramVec
+	tokenFallbacks prometheus.Counter
+}
+
+// New creates and registers the application's collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by route pattern, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route pattern and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		serviceCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "calls_total",
+			Help:      "Service calls by method and outcome.",
+		}, []string{"method", "outcome"}),
+		serviceLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "call_duration_seconds",
+			Help:      "Service call latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		storeLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "query_duration_seconds",
+			Help:      "Database query latency by store method and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "outcome"}),
+		tokenFallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "token_generator",
+			Name:      "fallbacks_total",
+			Help:      "Times the human-readable token generator failed and the fallback was used.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.serviceCalls,
+		m.serviceLatency,
+		m.storeLatency,
+		m.tokenFallbacks,
+	)
+
+	return m
+}
+
+// Register adds additional collectors to the registry.
+func (m *Metrics) Register(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
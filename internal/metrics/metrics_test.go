@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/generator"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestMiddlewareLabelsByRoutePattern(t *testing.T) {
+	m := New()
+
+	router := chi.NewRouter()
+	router.Use(m.Middleware)
+	router.Get("/game/{token}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, token := range []string{"hungry-hippo", "wretched-apostle"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/game/"+token, nil))
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/nope", nil))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/game/{token}", "GET", "418")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("unmatched", "GET", "404")))
+}
+
+type failingTokener struct{}
+
+func (failingTokener) NewToken(context.Context) (string, error) {
+	return "", errors.New("service unavailable")
+}
+
+func TestFallibleTokenerCountsFallbacks(t *testing.T) {
+	m := New()
+
+	g := generator.NewFallibleGenerator(m.FallibleTokener(failingTokener{}), generator.NewUUIDGenerator())
+	g.NewToken(context.Background())
+	g.NewToken(context.Background())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.tokenFallbacks))
+}
+
+func TestServiceOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: "ok"},
+		{err: fmt.Errorf("invalid input: %w", wording.InputViolations{"guess": {errors.New("too short")}}), want: "invalid"},
+		{err: service.ErrNotFound, want: "not_found"},
+		{err: service.ErrCannotContinue, want: "rejected"},
+		{err: errors.New("connection reset"), want: "error"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, serviceOutcome(tt.err))
+	}
+}
+
+func TestStatsGauges(t *testing.T) {
+	m := New()
+	m.RegisterStats(func(context.Context) (wording.Stats, error) {
+		return wording.Stats{GamesCreated: 3, GuessesMade: 20, GamesWon: 4}, nil
+	})
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{"wording_games_created 3", "wording_guesses_made 20", "wording_games_won 4"} {
+		assert.Assert(t, strings.Contains(body, want), want)
+	}
+}
@@ -0,0 +1,309 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+type instrumentedService struct {
+	m   *Metrics
+	svc service.Service
+}
+
+// Service decorates svc so that every call's latency and outcome is
+// recorded.
+func (m *Metrics) Service(svc service.Service) service.Service {
+	return &instrumentedService{m: m, svc: svc}
+}
+
+func (s *instrumentedService) observe(method string, start time.Time, err error) {
+	s.m.serviceCalls.WithLabelValues(method, serviceOutcome(err)).Inc()
+	s.m.serviceLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// serviceOutcome separates the errors that are a normal part of playing the
+// game from the ones that indicate something is actually wrong.
+func serviceOutcome(err error) string {
+	var violations wording.InputViolations
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.As(err, &violations):
+		return "invalid"
+	case errors.Is(err, service.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, service.ErrGuessLimitReached), errors.Is(err, service.ErrCannotContinue), errors.Is(err, service.ErrGameDisabled),
+		errors.Is(err, service.ErrGameNotOpen), errors.Is(err, service.ErrGameClosed):
+		return "rejected"
+	default:
+		return "error"
+	}
+}
+
+func (s *instrumentedService) CreateGame(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.svc.CreateGame(ctx, answer, guessLimit, settings, creator)
+	s.observe("CreateGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) DeleteGame(ctx context.Context, adminToken string) error {
+	start := time.Now()
+	err := s.svc.DeleteGame(ctx, adminToken)
+	s.observe("DeleteGame", start, err)
+	return err
+}
+
+func (s *instrumentedService) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.svc.Game(ctx, adminToken)
+	s.observe("Game", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.svc.GameByToken(ctx, token)
+	s.observe("GameByToken", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) GameState(ctx context.Context, gameToken, playerToken string) (*wording.GameState, error) {
+	start := time.Now()
+	v, err := s.svc.GameState(ctx, gameToken, playerToken)
+	s.observe("GameState", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	start := time.Now()
+	v, err := s.svc.GameStats(ctx, adminToken)
+	s.observe("GameStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error) {
+	start := time.Now()
+	v, err := s.svc.Plays(ctx, gameToken, playerToken)
+	s.observe("Plays", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) Stats(ctx context.Context) (wording.Stats, error) {
+	start := time.Now()
+	v, err := s.svc.Stats(ctx)
+	s.observe("Stats", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) SubmitGuess(ctx context.Context, gameToken, playerToken, guess string) error {
+	start := time.Now()
+	err := s.svc.SubmitGuess(ctx, gameToken, playerToken, guess)
+	s.observe("SubmitGuess", start, err)
+	return err
+}
+
+// NewPlayerToken allocates a new player token.
+func (s *instrumentedService) NewPlayerToken(ctx context.Context) string {
+	start := time.Now()
+	tok := s.svc.NewPlayerToken(ctx)
+	s.observe("NewPlayerToken", start, nil)
+	return tok
+}
+
+func (s *instrumentedService) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	start := time.Now()
+	v, err := s.svc.PruneGames(ctx, idleSince)
+	s.observe("PruneGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	start := time.Now()
+	v, err := s.svc.ExportGame(ctx, adminToken)
+	s.observe("ExportGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	start := time.Now()
+	err := s.svc.ExportGames(ctx, fn)
+	s.observe("ExportGames", start, err)
+	return err
+}
+
+func (s *instrumentedService) ImportGame(ctx context.Context, record *wording.GameRecord, freshTokens bool) (*wording.GameRecord, error) {
+	start := time.Now()
+	v, err := s.svc.ImportGame(ctx, record, freshTokens)
+	s.observe("ImportGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) SetStats(ctx context.Context, stats wording.Stats) error {
+	start := time.Now()
+	err := s.svc.SetStats(ctx, stats)
+	s.observe("SetStats", start, err)
+	return err
+}
+
+func (s *instrumentedService) DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error) {
+	start := time.Now()
+	v, err := s.svc.DailyStats(ctx, days)
+	s.observe("DailyStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error) {
+	start := time.Now()
+	v, err := s.svc.GameDailyStats(ctx, adminToken, days)
+	s.observe("GameDailyStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	start := time.Now()
+	v, err := s.svc.PlayerHistory(ctx, playerToken)
+	s.observe("PlayerHistory", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error) {
+	start := time.Now()
+	v, err := s.svc.PlayerStats(ctx, playerToken)
+	s.observe("PlayerStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) ClearPlayerHistory(ctx context.Context, playerToken string) (int, error) {
+	start := time.Now()
+	v, err := s.svc.ClearPlayerHistory(ctx, playerToken)
+	s.observe("ClearPlayerHistory", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error) {
+	start := time.Now()
+	v, err := s.svc.CreatedGames(ctx, creator, page)
+	s.observe("CreatedGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) MailEnabled() bool {
+	return s.svc.MailEnabled()
+}
+
+func (s *instrumentedService) RequestLogin(ctx context.Context, email string) error {
+	start := time.Now()
+	err := s.svc.RequestLogin(ctx, email)
+	s.observe("RequestLogin", start, err)
+	return err
+}
+
+func (s *instrumentedService) Login(ctx context.Context, loginToken, playerToken string) (*wording.Session, error) {
+	start := time.Now()
+	v, err := s.svc.Login(ctx, loginToken, playerToken)
+	s.observe("Login", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) Session(ctx context.Context, sessionToken string) (*wording.Account, error) {
+	start := time.Now()
+	v, err := s.svc.Session(ctx, sessionToken)
+	s.observe("Session", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) Logout(ctx context.Context, sessionToken string) error {
+	start := time.Now()
+	err := s.svc.Logout(ctx, sessionToken)
+	s.observe("Logout", start, err)
+	return err
+}
+
+func (s *instrumentedService) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	start := time.Now()
+	v, err := s.svc.DeleteCreatedGames(ctx, creator, adminTokens)
+	s.observe("DeleteCreatedGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) RotateAdminToken(ctx context.Context, adminToken string) (string, error) {
+	start := time.Now()
+	v, err := s.svc.RotateAdminToken(ctx, adminToken)
+	s.observe("RotateAdminToken", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) SetRecoveryEmail(ctx context.Context, adminToken, email string) error {
+	start := time.Now()
+	err := s.svc.SetRecoveryEmail(ctx, adminToken, email)
+	s.observe("SetRecoveryEmail", start, err)
+	return err
+}
+
+func (s *instrumentedService) RequestRecovery(ctx context.Context, email string) error {
+	start := time.Now()
+	err := s.svc.RequestRecovery(ctx, email)
+	s.observe("RequestRecovery", start, err)
+	return err
+}
+
+func (s *instrumentedService) RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error) {
+	start := time.Now()
+	v, err := s.svc.RecoverGames(ctx, recoveryToken)
+	s.observe("RecoverGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error) {
+	start := time.Now()
+	v, err := s.svc.SearchGames(ctx, search, page)
+	s.observe("SearchGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	start := time.Now()
+	v, err := s.svc.InspectGame(ctx, operator, adminToken)
+	s.observe("InspectGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	start := time.Now()
+	err := s.svc.SetGameDisabled(ctx, operator, adminToken, disabled)
+	s.observe("SetGameDisabled", start, err)
+	return err
+}
+
+func (s *instrumentedService) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	start := time.Now()
+	err := s.svc.OperatorDeleteGame(ctx, operator, adminToken)
+	s.observe("OperatorDeleteGame", start, err)
+	return err
+}
+
+func (s *instrumentedService) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	start := time.Now()
+	v, err := s.svc.AuditLog(ctx, limit)
+	s.observe("AuditLog", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error) {
+	start := time.Now()
+	v, err := s.svc.Leaderboard(ctx, gameToken)
+	s.observe("Leaderboard", start, err)
+	return v, err
+}
+
+func (s *instrumentedService) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	start := time.Now()
+	err := s.svc.UpdateGameSettings(ctx, adminToken, settings)
+	s.observe("UpdateGameSettings", start, err)
+	return err
+}
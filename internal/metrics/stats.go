@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// StatsFunc fetches the application's lifetime stats.
+type StatsFunc func(ctx context.Context) (wording.Stats, error)
+
+type statsCollector struct {
+	stats StatsFunc
+
+	gamesCreated *prometheus.Desc
+	guessesMade  *prometheus.Desc
+	gamesWon     *prometheus.Desc
+}
+
+// RegisterStats exposes the lifetime stats as gauges. The stats are read
+// from the store on every scrape, so every replica reports the same,
+// instance-wide totals.
+func (m *Metrics) RegisterStats(stats StatsFunc) {
+	m.Register(&statsCollector{
+		stats:        stats,
+		gamesCreated: prometheus.NewDesc(namespace+"_games_created", "Games created over the lifetime of the instance.", nil, nil),
+		guessesMade:  prometheus.NewDesc(namespace+"_guesses_made", "Guesses made over the lifetime of the instance.", nil, nil),
+		gamesWon:     prometheus.NewDesc(namespace+"_games_won", "Games won over the lifetime of the instance.", nil, nil),
+	})
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.gamesCreated
+	ch <- c.guessesMade
+	ch <- c.gamesWon
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	stats, err := c.stats(ctx)
+	if err != nil {
+		log.WithError(err).Warn("collecting stats metrics")
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.gamesCreated, prometheus.GaugeValue, float64(stats.GamesCreated))
+	ch <- prometheus.MustNewConstMetric(c.guessesMade, prometheus.GaugeValue, float64(stats.GuessesMade))
+	ch <- prometheus.MustNewConstMetric(c.gamesWon, prometheus.GaugeValue, float64(stats.GamesWon))
+}
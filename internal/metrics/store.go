@@ -0,0 +1,274 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+type instrumentedStore struct {
+	m     *Metrics
+	store service.Store
+}
+
+// Store decorates a store so that the latency of every query is recorded.
+func (m *Metrics) Store(s service.Store) service.Store {
+	return &instrumentedStore{m: m, store: s}
+}
+
+func (s *instrumentedStore) observe(method string, start time.Time, err error) {
+	o := outcome(err)
+	if errors.Is(err, store.ErrNotFound) {
+		o = "not_found"
+	}
+	s.m.storeLatency.WithLabelValues(method, o).Observe(time.Since(start).Seconds())
+}
+
+func (s *instrumentedStore) CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.store.CreateGame(ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)
+	s.observe("CreateGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.store.Game(ctx, adminToken)
+	s.observe("Game", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
+	start := time.Now()
+	v, err := s.store.GameByToken(ctx, token)
+	s.observe("GameByToken", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error) {
+	start := time.Now()
+	v, err := s.store.Plays(ctx, gameToken, playerToken)
+	s.observe("Plays", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome) error {
+	start := time.Now()
+	err := s.store.PutPlays(ctx, gameToken, playerToken, plays, outcome)
+	s.observe("PutPlays", start, err)
+	return err
+}
+
+func (s *instrumentedStore) IncrementStats(ctx context.Context, stats wording.IncrementStats) error {
+	start := time.Now()
+	err := s.store.IncrementStats(ctx, stats)
+	s.observe("IncrementStats", start, err)
+	return err
+}
+
+func (s *instrumentedStore) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	start := time.Now()
+	v, err := s.store.GameStats(ctx, adminToken)
+	s.observe("GameStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) Stats(ctx context.Context) (wording.Stats, error) {
+	start := time.Now()
+	v, err := s.store.Stats(ctx)
+	s.observe("Stats", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) DeleteGame(ctx context.Context, adminToken string) error {
+	start := time.Now()
+	err := s.store.DeleteGame(ctx, adminToken)
+	s.observe("DeleteGame", start, err)
+	return err
+}
+
+func (s *instrumentedStore) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	start := time.Now()
+	v, err := s.store.PruneGames(ctx, idleSince)
+	s.observe("PruneGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	start := time.Now()
+	err := s.store.ExportGames(ctx, fn)
+	s.observe("ExportGames", start, err)
+	return err
+}
+
+func (s *instrumentedStore) GameRecord(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	start := time.Now()
+	v, err := s.store.GameRecord(ctx, adminToken)
+	s.observe("GameRecord", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) ImportGame(ctx context.Context, record *wording.GameRecord) error {
+	start := time.Now()
+	err := s.store.ImportGame(ctx, record)
+	s.observe("ImportGame", start, err)
+	return err
+}
+
+func (s *instrumentedStore) SetStats(ctx context.Context, stats wording.Stats) error {
+	start := time.Now()
+	err := s.store.SetStats(ctx, stats)
+	s.observe("SetStats", start, err)
+	return err
+}
+
+func (s *instrumentedStore) DailyStats(ctx context.Context, since time.Time) ([]wording.DailyStats, error) {
+	start := time.Now()
+	v, err := s.store.DailyStats(ctx, since)
+	s.observe("DailyStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) GameDailyStats(ctx context.Context, adminToken string, since time.Time) ([]wording.DailyStats, error) {
+	start := time.Now()
+	v, err := s.store.GameDailyStats(ctx, adminToken, since)
+	s.observe("GameDailyStats", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) PlayerGames(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	start := time.Now()
+	v, err := s.store.PlayerGames(ctx, playerToken)
+	s.observe("PlayerGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) DeletePlayerGames(ctx context.Context, playerToken string) (int, error) {
+	start := time.Now()
+	v, err := s.store.DeletePlayerGames(ctx, playerToken)
+	s.observe("DeletePlayerGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) CreateLoginToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	start := time.Now()
+	err := s.store.CreateLoginToken(ctx, tokenHash, emailHash, expiresAt)
+	s.observe("CreateLoginToken", start, err)
+	return err
+}
+
+func (s *instrumentedStore) StartSession(ctx context.Context, loginTokenHash, playerToken, newPlayerToken, sessionHash string, expiresAt time.Time) (*wording.Account, error) {
+	start := time.Now()
+	v, err := s.store.StartSession(ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)
+	s.observe("StartSession", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) AccountSession(ctx context.Context, sessionHash string) (*wording.Account, error) {
+	start := time.Now()
+	v, err := s.store.AccountSession(ctx, sessionHash)
+	s.observe("AccountSession", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) DeleteSession(ctx context.Context, sessionHash string) error {
+	start := time.Now()
+	err := s.store.DeleteSession(ctx, sessionHash)
+	s.observe("DeleteSession", start, err)
+	return err
+}
+
+func (s *instrumentedStore) CreatedGames(ctx context.Context, creator string, limit, offset int) ([]wording.CreatedGame, error) {
+	start := time.Now()
+	v, err := s.store.CreatedGames(ctx, creator, limit, offset)
+	s.observe("CreatedGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	start := time.Now()
+	v, err := s.store.DeleteCreatedGames(ctx, creator, adminTokens)
+	s.observe("DeleteCreatedGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) RotateAdminToken(ctx context.Context, adminToken, newAdminToken string) error {
+	start := time.Now()
+	err := s.store.RotateAdminToken(ctx, adminToken, newAdminToken)
+	s.observe("RotateAdminToken", start, err)
+	return err
+}
+
+func (s *instrumentedStore) SetRecoveryEmail(ctx context.Context, adminToken, emailHash string) error {
+	start := time.Now()
+	err := s.store.SetRecoveryEmail(ctx, adminToken, emailHash)
+	s.observe("SetRecoveryEmail", start, err)
+	return err
+}
+
+func (s *instrumentedStore) RecoverableGames(ctx context.Context, emailHash string) ([]wording.Game, error) {
+	start := time.Now()
+	v, err := s.store.RecoverableGames(ctx, emailHash)
+	s.observe("RecoverableGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) CreateRecoveryToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	start := time.Now()
+	err := s.store.CreateRecoveryToken(ctx, tokenHash, emailHash, expiresAt)
+	s.observe("CreateRecoveryToken", start, err)
+	return err
+}
+
+func (s *instrumentedStore) UseRecoveryToken(ctx context.Context, tokenHash string) (string, error) {
+	start := time.Now()
+	v, err := s.store.UseRecoveryToken(ctx, tokenHash)
+	s.observe("UseRecoveryToken", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) SearchGames(ctx context.Context, search wording.GameSearch, limit, offset int) ([]wording.ListedGame, error) {
+	start := time.Now()
+	v, err := s.store.SearchGames(ctx, search, limit, offset)
+	s.observe("SearchGames", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	start := time.Now()
+	v, err := s.store.InspectGame(ctx, operator, adminToken)
+	s.observe("InspectGame", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	start := time.Now()
+	err := s.store.SetGameDisabled(ctx, operator, adminToken, disabled)
+	s.observe("SetGameDisabled", start, err)
+	return err
+}
+
+func (s *instrumentedStore) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	start := time.Now()
+	err := s.store.OperatorDeleteGame(ctx, operator, adminToken)
+	s.observe("OperatorDeleteGame", start, err)
+	return err
+}
+
+func (s *instrumentedStore) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	start := time.Now()
+	v, err := s.store.AuditLog(ctx, limit)
+	s.observe("AuditLog", start, err)
+	return v, err
+}
+
+func (s *instrumentedStore) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	start := time.Now()
+	err := s.store.UpdateGameSettings(ctx, adminToken, settings)
+	s.observe("UpdateGameSettings", start, err)
+	return err
+}
@@ -6,10 +6,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -24,16 +26,20 @@ type Client struct {
 func NewClient(baseURL string) *Client {
 	return &Client{
 		baseURL: baseURL,
+		client: http.Client{
+			// Propagates the caller's trace context to the word API.
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
 	}
 }
 
 // HumanReadableSlug returns a randomly generated URL slug comprised of
 // hyphen-separated words.
-func (c *Client) HumanReadableSlug() (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (c *Client) HumanReadableSlug(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	tasks, _ := errgroup.WithContext(ctx)
+	tasks, ctx := errgroup.WithContext(ctx)
 
 	var (
 		adjective string
@@ -43,17 +49,17 @@ func (c *Client) HumanReadableSlug() (string, error) {
 
 	tasks.Go(func() error {
 		var err error
-		adjective, err = c.Adjective()
+		adjective, err = c.Adjective(ctx)
 		return err
 	})
 	tasks.Go(func() error {
 		var err error
-		noun, err = c.Noun()
+		noun, err = c.Noun(ctx)
 		return err
 	})
 	tasks.Go(func() error {
 		var err error
-		animal, err = c.Animal()
+		animal, err = c.Animal(ctx)
 		return err
 	})
 	err := tasks.Wait()
@@ -65,29 +71,38 @@ func (c *Client) HumanReadableSlug() (string, error) {
 }
 
 // Noun requests a random noun.
-func (c *Client) Noun() (string, error) {
-	return c.getWord("/random/noun")
+func (c *Client) Noun(ctx context.Context) (string, error) {
+	return c.getWord(ctx, "/random/noun")
 }
 
 // Adjective requests a random adjective.
-func (c *Client) Adjective() (string, error) {
-	return c.getWord("/random/adjective")
+func (c *Client) Adjective(ctx context.Context) (string, error) {
+	return c.getWord(ctx, "/random/adjective")
 }
 
 // Animal requests a random animal name.
-func (c *Client) Animal() (string, error) {
-	return c.getWord("/random/animal")
+func (c *Client) Animal(ctx context.Context) (string, error) {
+	return c.getWord(ctx, "/random/animal")
 }
 
-func (c *Client) getWord(endpoint string) (string, error) {
+func (c *Client) getWord(ctx context.Context, endpoint string) (string, error) {
 	url := c.baseURL + endpoint
 
-	rsp, err := c.client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	rsp, err := c.client.Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer rsp.Body.Close()
 
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("service returned %s", rsp.Status)
+	}
+
 	var words []string
 	err = json.NewDecoder(rsp.Body).Decode(&words)
 	if err != nil {
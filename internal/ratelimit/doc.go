@@ -0,0 +1,2 @@
+// Package ratelimit throttles requests with token buckets.
+package ratelimit
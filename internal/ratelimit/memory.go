@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Memory is an in-process token bucket Limiter. Each key gets its own
+// bucket.
+type Memory struct {
+	rule Rule
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemory creates a new in-process limiter enforcing rule.
+func NewMemory(rule Rule) *Memory {
+	return &Memory{
+		rule:    rule,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from key's bucket if one is available.
+func (m *Memory) Allow(_ context.Context, key string) (Decision, error) {
+	if !m.rule.Enabled() {
+		return Decision{Allowed: true}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	capacity := float64(m.rule.Requests)
+	rate := capacity / m.rule.Per.Seconds()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return Decision{Allowed: true}, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return Decision{RetryAfter: wait}, nil
+}
+
+// Run periodically forgets buckets that have refilled completely, since
+// they are indistinguishable from a new bucket. It blocks until ctx is
+// cancelled.
+func (m *Memory) Run(ctx context.Context) {
+	if !m.rule.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(m.rule.Per)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.evict()
+		}
+	}
+}
+
+func (m *Memory) evict() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for key, b := range m.buckets {
+		if now.Sub(b.last) >= m.rule.Per {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decision is the outcome of asking a Limiter for permission.
+type Decision struct {
+	Allowed bool
+	// RetryAfter is how long the caller should wait before trying again
+	// when the request was not allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter decides whether the caller identified by key may proceed.
+//
+// Memory is the in-process implementation. Backends shared between
+// replicas (e.g., Redis) can be plugged in by implementing this interface.
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Decision, error)
+}
+
+// Rule allows bursts of up to Requests requests, refilling at a rate of
+// Requests every Per. The zero Rule disables limiting.
+type Rule struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Enabled reports whether the rule limits anything.
+func (r Rule) Enabled() bool {
+	return r.Requests > 0 && r.Per > 0
+}
+
+// String formats the rule the same way ParseRule accepts it.
+func (r Rule) String() string {
+	if !r.Enabled() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%s", r.Requests, r.Per)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseRule.
+func (r *Rule) UnmarshalText(text []byte) error {
+	rule, err := ParseRule(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseRule parses a rule such as "10/m", "100/h" or "5/30s". An empty
+// string or "off" yields the zero Rule.
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		return Rule{}, nil
+	}
+
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("rate limit %q: expected <requests>/<period>", s)
+	}
+
+	requests, err := strconv.Atoi(count)
+	if err != nil || requests < 1 {
+		return Rule{}, fmt.Errorf("rate limit %q: requests must be a positive number", s)
+	}
+
+	per, ok := units[period]
+	if !ok {
+		per, err = time.ParseDuration(period)
+		if err != nil || per <= 0 {
+			return Rule{}, fmt.Errorf("rate limit %q: invalid period %q", s, period)
+		}
+	}
+
+	return Rule{Requests: requests, Per: per}, nil
+}
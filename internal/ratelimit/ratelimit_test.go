@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Rule
+		wantErr bool
+	}{
+		{in: "", want: Rule{}},
+		{in: "off", want: Rule{}},
+		{in: "10/m", want: Rule{Requests: 10, Per: time.Minute}},
+		{in: "100/h", want: Rule{Requests: 100, Per: time.Hour}},
+		{in: "5/30s", want: Rule{Requests: 5, Per: 30 * time.Second}},
+		{in: "10", wantErr: true},
+		{in: "0/m", wantErr: true},
+		{in: "ten/m", wantErr: true},
+		{in: "10/fortnight", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRule(tt.in)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMemory(t *testing.T) {
+	now := time.Unix(0, 0)
+	m := NewMemory(Rule{Requests: 2, Per: time.Minute})
+	m.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	allow := func(key string) Decision {
+		d, err := m.Allow(ctx, key)
+		assert.NilError(t, err)
+		return d
+	}
+
+	assert.Assert(t, allow("a").Allowed)
+	assert.Assert(t, allow("a").Allowed)
+
+	d := allow("a")
+	assert.Assert(t, !d.Allowed)
+	assert.Equal(t, 30*time.Second, d.RetryAfter)
+
+	// Other keys have their own bucket.
+	assert.Assert(t, allow("b").Allowed)
+
+	now = now.Add(30 * time.Second)
+	assert.Assert(t, allow("a").Allowed)
+	assert.Assert(t, !allow("a").Allowed)
+
+	now = now.Add(time.Minute)
+	m.evict()
+	assert.Equal(t, 0, len(m.buckets))
+}
+
+func TestMemoryDisabled(t *testing.T) {
+	m := NewMemory(Rule{})
+	for i := 0; i < 100; i++ {
+		d, err := m.Allow(context.Background(), "a")
+		assert.NilError(t, err)
+		assert.Assert(t, d.Allowed)
+	}
+}
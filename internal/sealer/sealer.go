@@ -0,0 +1,137 @@
+// Package sealer encrypts values stored at rest, such as game answers, so
+// that reading the database or a backup doesn't reveal them.
+package sealer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownKey means the value was sealed with a key the sealer
+	// doesn't have.
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrInvalidSealed means the value was tampered with, sealed with
+	// different associated data, or isn't a sealed value at all.
+	ErrInvalidSealed = errors.New("invalid sealed value")
+	// ErrNoKeys means a sealer was requested without any keys.
+	ErrNoKeys = errors.New("at least one key is required")
+)
+
+// Key is an AES key along with the ID recorded next to values it seals.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// ParseKey parses a key written as ID:BASE64, where the secret decodes to
+// 16, 24 or 32 bytes for AES-128, AES-192 or AES-256.
+func ParseKey(s string) (Key, error) {
+	id, encoded, ok := strings.Cut(s, ":")
+	if !ok || id == "" {
+		return Key{}, errors.New("key must be written as ID:BASE64")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return Key{}, fmt.Errorf("key %s is not valid base64", id)
+	}
+
+	switch len(secret) {
+	case 16, 24, 32:
+	default:
+		return Key{}, fmt.Errorf("key %s must be 16, 24 or 32 bytes, not %d", id, len(secret))
+	}
+
+	return Key{ID: id, Secret: secret}, nil
+}
+
+// Sealer seals values with AES-GCM.
+//
+// The first key seals new values and every key can open them, which allows
+// keys to be rotated: add the new key to the front of the list, re-seal
+// everything sealed with the old one, then drop it.
+type Sealer struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// New creates a new Sealer. The first key is the active sealing key.
+func New(keys ...Key) (*Sealer, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	s := &Sealer{
+		current: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, ok := s.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("key %s is given twice", key.ID)
+		}
+
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+
+		s.aeads[key.ID] = aead
+	}
+
+	return s, nil
+}
+
+// KeyID returns the ID of the key new values are sealed with.
+func (s *Sealer) KeyID() string {
+	return s.current
+}
+
+// Seal encrypts value with the active key. The same associated data must
+// be given to open it, which ties the sealed value to, e.g., the row it's
+// stored in. It returns the ID of the key used along with the sealed value.
+func (s *Sealer) Seal(value, associatedData string) (keyID, sealed string, err error) {
+	aead := s.aeads[s.current]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	b := aead.Seal(nonce, nonce, []byte(value), []byte(associatedData))
+
+	return s.current, base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Open decrypts a value sealed with the key identified by keyID.
+func (s *Sealer) Open(keyID, sealed, associatedData string) (string, error) {
+	aead, ok := s.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(b) < aead.NonceSize() {
+		return "", ErrInvalidSealed
+	}
+
+	nonce, ciphertext := b[:aead.NonceSize()], b[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, []byte(associatedData))
+	if err != nil {
+		return "", ErrInvalidSealed
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,103 @@
+package sealer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var (
+	oldKey = Key{ID: "2022", Secret: []byte(strings.Repeat("o", 32))}
+	newKey = Key{ID: "2023", Secret: []byte(strings.Repeat("n", 16))}
+)
+
+func TestSealOpen(t *testing.T) {
+	s, err := New(newKey, oldKey)
+	assert.NilError(t, err)
+
+	keyID, sealed, err := s.Seal("potato", "wretched-apostle")
+	assert.NilError(t, err)
+	assert.Equal(t, "2023", keyID)
+	assert.Assert(t, !strings.Contains(sealed, "potato"))
+
+	got, err := s.Open(keyID, sealed, "wretched-apostle")
+	assert.NilError(t, err)
+	assert.Equal(t, "potato", got)
+
+	_, again, err := s.Seal("potato", "wretched-apostle")
+	assert.NilError(t, err)
+	assert.Assert(t, sealed != again, "every value must get its own nonce")
+}
+
+func TestOpenWithOlderKey(t *testing.T) {
+	old, err := New(oldKey)
+	assert.NilError(t, err)
+
+	keyID, sealed, err := old.Seal("potato", "wretched-apostle")
+	assert.NilError(t, err)
+
+	rotated, err := New(newKey, oldKey)
+	assert.NilError(t, err)
+
+	got, err := rotated.Open(keyID, sealed, "wretched-apostle")
+	assert.NilError(t, err)
+	assert.Equal(t, "potato", got)
+}
+
+func TestOpenRejects(t *testing.T) {
+	s, err := New(newKey, oldKey)
+	assert.NilError(t, err)
+
+	keyID, sealed, err := s.Seal("potato", "wretched-apostle")
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name   string
+		keyID  string
+		sealed string
+		ad     string
+		want   error
+	}{
+		{name: "unknown key", keyID: "1999", sealed: sealed, ad: "wretched-apostle", want: ErrUnknownKey},
+		{name: "wrong key", keyID: oldKey.ID, sealed: sealed, ad: "wretched-apostle", want: ErrInvalidSealed},
+		{name: "other row", keyID: keyID, sealed: sealed, ad: "hungry-hippo", want: ErrInvalidSealed},
+		{name: "plaintext", keyID: keyID, sealed: "potato", ad: "wretched-apostle", want: ErrInvalidSealed},
+		{name: "truncated", keyID: keyID, sealed: "AAAA", ad: "wretched-apostle", want: ErrInvalidSealed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Open(tt.keyID, tt.sealed, tt.ad)
+			assert.Assert(t, errors.Is(err, tt.want), err)
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey("2023:" + "bm5ubm5ubm5ubm5ubm5ubg==")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, newKey, key)
+
+	for _, bad := range []string{
+		"bm5ubm5ubm5ubm5ubm5ubg==",
+		":bm5ubm5ubm5ubm5ubm5ubg==",
+		"2023:not base64!",
+		"2023:c2hvcnQ=",
+	} {
+		_, err := ParseKey(bad)
+		assert.Assert(t, err != nil, "%q should be rejected", bad)
+	}
+}
+
+func TestNewRejects(t *testing.T) {
+	_, err := New()
+	assert.Equal(t, ErrNoKeys, err)
+
+	_, err = New(newKey, newKey)
+	assert.ErrorContains(t, err, "twice")
+
+	_, err = New(Key{ID: "short", Secret: []byte("short")})
+	assert.ErrorContains(t, err, "short")
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/view"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+const sessionCookie = "WordingSession"
+
+type accountKey struct{}
+
+func withAccount(ctx context.Context, account *wording.Account) context.Context {
+	return context.WithValue(ctx, accountKey{}, account)
+}
+
+// account returns the account the request is logged in to, or nil.
+func account(ctx context.Context) *wording.Account {
+	a, _ := ctx.Value(accountKey{}).(*wording.Account)
+	return a
+}
+
+// sessionToken returns the session token from the request's signed
+// session cookie, if it has one.
+func (s *Server) sessionToken(r *http.Request) (string, bool) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+
+	token, err := s.signer.Verify(c.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return token, true
+}
+
+// loggedIn returns the account the request's session is logged in to. A
+// session that has expired or was logged out has its cookie removed.
+func (s *Server) loggedIn(ctx context.Context, w http.ResponseWriter, r *http.Request) *wording.Account {
+	token, ok := s.sessionToken(r)
+	if !ok {
+		return nil
+	}
+
+	account, err := s.svc.Session(ctx, token)
+	if errors.Is(err, service.ErrNotFound) {
+		s.clearSessionCookie(w)
+		return nil
+	}
+	if err != nil {
+		// Carry on anonymously rather than failing the whole request.
+		logging.From(ctx, s.log).WithError(err).Warn("reading session")
+		return nil
+	}
+
+	return account
+}
+
+func (s *Server) setSessionCookie(w http.ResponseWriter, session *wording.Session) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    s.signer.Sign(session.Token),
+		Path:     "/",
+		MaxAge:   int(time.Until(session.ExpiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.baseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *Server) clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.baseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// LoginPage asks for an email address to send a login link to.
+func (s *Server) LoginPage(w http.ResponseWriter, r *http.Request) {
+	if !s.svc.MailEnabled() {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	err := view.Login{
+		CSRFToken: csrfToken(r.Context()),
+		Sent:      r.URL.Query().Get("sent") != "",
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// RequestLogin emails a login link to the address in the form.
+func (s *Server) RequestLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_ = r.ParseForm()
+
+	err := s.svc.RequestLogin(ctx, r.PostFormValue("email"))
+	var violations wording.InputViolations
+	if errors.As(err, &violations) {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(": %v", err), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrMailDisabled) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Error("sending login link")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/login?sent=1", http.StatusSeeOther)
+}
+
+// ConfirmLogin is where login links lead. Logging in takes another click,
+// so that mail scanners which follow links don't use them up.
+func (s *Server) ConfirmLogin(w http.ResponseWriter, r *http.Request) {
+	err := view.Login{
+		CSRFToken: csrfToken(r.Context()),
+		Token:     chi.URLParam(r, "token"),
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Login uses up a login link and logs the browser in. Whatever was played
+// or created in the browser is merged into the account.
+func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Logging in again would merge one account into another.
+	if account(ctx) != nil {
+		http.Redirect(w, r, "/my-games", http.StatusSeeOther)
+		return
+	}
+
+	session, err := s.svc.Login(ctx, chi.URLParam(r, "token"), playerToken(ctx))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound)+": this login link has expired or has already been used", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Error("logging in")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	s.setSessionCookie(w, session)
+	http.Redirect(w, r, "/my-games", http.StatusSeeOther)
+}
+
+// Logout ends the browser's session. The browser starts over as a new
+// player, so nothing played or created by the account stays reachable
+// from it.
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if token, ok := s.sessionToken(r); ok {
+		err := s.svc.Logout(ctx, token)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	s.clearSessionCookie(w)
+	s.issuePlayerToken(w, s.svc.NewPlayerToken(ctx))
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
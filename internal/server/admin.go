@@ -0,0 +1,314 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/view"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// adminTimeLayout is how times are shown in the operator console.
+const adminTimeLayout = "2006-01-02 15:04:05"
+
+// adminStatsDays is how many days of stats the operator console shows.
+const adminStatsDays = 14
+
+// ErrorLog remembers the errors logged recently.
+type ErrorLog interface {
+	Recent() []logging.LoggedError
+}
+
+// Admin is the operator console, where the people running the site can
+// look into games and take down the ones that shouldn't be there.
+type Admin struct {
+	baseURL string
+	svc     Service
+	auth    OperatorAuth
+	errors  ErrorLog
+	log     logrus.FieldLogger
+}
+
+// NewAdmin creates the operator console. Operators are let in by auth.
+func NewAdmin(baseURL string, svc Service, auth OperatorAuth, errors ErrorLog, logger logrus.FieldLogger) *Admin {
+	return &Admin{
+		baseURL: baseURL,
+		svc:     svc,
+		auth:    auth,
+		errors:  errors,
+		log:     logger,
+	}
+}
+
+// Authenticate is middleware that only lets operators through.
+func (a *Admin) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := a.auth.Authenticate(w, r)
+		if !ok {
+			return
+		}
+
+		// Pages show answers, so they mustn't be kept anywhere.
+		w.Header().Set("Cache-Control", "no-store")
+
+		ctx := logging.AddFields(r.Context(), logrus.Fields{"operator": name})
+		next.ServeHTTP(w, r.WithContext(withOperator(ctx, name)))
+	})
+}
+
+// Overview shows the site's stats, recent errors and the audit log.
+func (a *Admin) Overview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats, err := a.svc.Stats(ctx)
+	if err != nil {
+		logging.From(ctx, a.log).WithError(err).Warn("reading stats")
+	}
+
+	days, err := a.svc.DailyStats(ctx, adminStatsDays)
+	if err != nil {
+		logging.From(ctx, a.log).WithError(err).Warn("reading daily stats")
+	}
+
+	audit, err := a.svc.AuditLog(ctx, service.AuditLogLength)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	recent := a.errors.Recent()
+	loggedErrors := make([]view.LoggedError, len(recent))
+	for i, e := range recent {
+		loggedErrors[i] = view.LoggedError{
+			Time:    e.Time.UTC().Format(adminTimeLayout),
+			Message: e.Message,
+			Fields:  e.Fields,
+		}
+	}
+
+	entries := make([]view.AuditEntry, len(audit))
+	for i, e := range audit {
+		entries[i] = view.AuditEntry{
+			Time:      e.Time.UTC().Format(adminTimeLayout),
+			Operator:  e.Operator,
+			Action:    e.Action,
+			GameToken: e.GameToken,
+		}
+	}
+
+	err = view.Admin{
+		Operator: operator(ctx),
+		Stats:    stats,
+		Days:     daysView(days),
+		Errors:   loggedErrors,
+		Audit:    entries,
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Games searches for games by token, when they were created, or how
+// recently they were active.
+func (a *Admin) Games(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := r.URL.Query()
+
+	search := wording.GameSearch{
+		Token:      strings.TrimSpace(q.Get("token")),
+		ByActivity: q.Get("sort") == "activity",
+	}
+
+	var err error
+	if v := q.Get("from"); v != "" {
+		search.CreatedFrom, err = time.Parse(api.DateLayout, v)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest)+" from must be a date", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		to, err := time.Parse(api.DateLayout, v)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest)+" to must be a date", http.StatusBadRequest)
+			return
+		}
+		search.CreatedBefore = to.AddDate(0, 0, 1)
+	}
+
+	page := 1
+	if v := q.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, http.StatusText(http.StatusBadRequest)+" page must be a positive number", http.StatusBadRequest)
+			return
+		}
+		page = n
+	}
+
+	listed, err := a.svc.SearchGames(ctx, search, page)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	games := make([]view.ListedGame, len(listed.Games))
+	for i := range listed.Games {
+		g := &listed.Games[i]
+		games[i] = view.ListedGame{
+			AdminToken: g.AdminToken,
+			Token:      g.Token,
+			Created:    g.CreatedAt.UTC().Format(adminTimeLayout),
+			Accessed:   g.AccessedAt.UTC().Format(adminTimeLayout),
+			GuessLimit: g.GuessLimit,
+			Players:    g.Players,
+			Disabled:   g.Disabled,
+		}
+	}
+
+	pageURL := func(page int) string {
+		q := url.Values{}
+		for _, k := range []string{"token", "from", "to", "sort"} {
+			if v := r.URL.Query().Get(k); v != "" {
+				q.Set(k, v)
+			}
+		}
+		q.Set("page", strconv.Itoa(page))
+		return "/admin/games?" + q.Encode()
+	}
+
+	adminGames := view.AdminGames{
+		Operator:   operator(ctx),
+		Token:      search.Token,
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+		ByActivity: search.ByActivity,
+		Games:      games,
+		Page:       listed.Page,
+		Deleted:    q.Get("deleted") != "",
+	}
+	if listed.Page > 1 {
+		adminGames.PrevURL = pageURL(listed.Page - 1)
+	}
+	if listed.More {
+		adminGames.NextURL = pageURL(listed.Page + 1)
+	}
+
+	err = adminGames.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Game shows a game's answer and everything played against it. Looking is
+// recorded in the audit log.
+func (a *Admin) Game(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	record, err := a.svc.InspectGame(ctx, operator(ctx), chi.URLParam(r, "admin_token"))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	players := make([]view.AdminPlayer, len(record.Players))
+	for i := range record.Players {
+		p := &record.Players[i]
+
+		result := "playing"
+		state := p.Evaluate(record.Answer, record.GuessLimit)
+		switch {
+		case state.IsVictorious:
+			result = "won"
+		case state.GameOver:
+			result = "lost"
+		}
+
+		players[i] = view.AdminPlayer{
+			Player:   logging.HashToken(p.PlayerToken),
+			Started:  p.CreatedAt.UTC().Format(adminTimeLayout),
+			Attempts: p.Attempts,
+			Result:   result,
+		}
+	}
+
+	err = view.AdminGame{
+		CSRFToken:  csrfToken(ctx),
+		BaseURL:    a.baseURL,
+		AdminToken: record.AdminToken,
+		Token:      record.Token,
+		Answer:     record.Answer,
+		GuessLimit: record.GuessLimit,
+		Created:    record.CreatedAt.UTC().Format(adminTimeLayout),
+		Disabled:   record.Disabled,
+		Difficulty: difficultyView(&record.Game),
+		Players:    players,
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DisableGame stops a game from being played.
+func (a *Admin) DisableGame(w http.ResponseWriter, r *http.Request) {
+	a.setGameDisabled(w, r, true)
+}
+
+// EnableGame lets a disabled game be played again.
+func (a *Admin) EnableGame(w http.ResponseWriter, r *http.Request) {
+	a.setGameDisabled(w, r, false)
+}
+
+func (a *Admin) setGameDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	ctx := r.Context()
+
+	adminToken := chi.URLParam(r, "admin_token")
+
+	err := a.svc.SetGameDisabled(ctx, operator(ctx), adminToken, disabled)
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/games/%s", adminToken), http.StatusSeeOther)
+}
+
+// DeleteGame deletes a game and everything played against it.
+func (a *Admin) DeleteGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := a.svc.OperatorDeleteGame(ctx, operator(ctx), chi.URLParam(r, "admin_token"))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/games?deleted=1", http.StatusSeeOther)
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestBasicAuth(t *testing.T) {
+	admin := NewAdmin("http://localhost:8080", NewMockService(t), NewBasicAuth("alice", "hunter2"), logging.NewRecentErrors(1), newTestLogger())
+
+	var seen string
+	handler := admin.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = operator(r.Context())
+	}))
+
+	for _, tc := range []struct {
+		name, username, password string
+		want                     int
+	}{
+		{name: "missing", want: http.StatusUnauthorized},
+		{name: "wrong password", username: "alice", password: "hunter3", want: http.StatusUnauthorized},
+		{name: "wrong username", username: "bob", password: "hunter2", want: http.StatusUnauthorized},
+		{name: "right", username: "alice", password: "hunter2", want: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = ""
+
+			r := httptest.NewRequest("GET", "/admin", nil)
+			if tc.username != "" {
+				r.SetBasicAuth(tc.username, tc.password)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tc.want, w.Code, w.Body)
+
+			if tc.want == http.StatusOK {
+				assert.Equal(t, "alice", seen)
+				assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			} else {
+				assert.Equal(t, "", seen)
+				assert.Assert(t, w.Header().Get("WWW-Authenticate") != "")
+			}
+		})
+	}
+}
+
+func TestAdminGame(t *testing.T) {
+	svc := NewMockService(t)
+	admin := NewAdmin("http://localhost:8080", svc, StubAuth("alice"), logging.NewRecentErrors(1), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Use(admin.Authenticate)
+	router.Get("/admin/games/{admin_token}", admin.Game)
+
+	svc.EXPECT().
+		InspectGame(mock.Anything, "alice", "wretched-apostle").
+		Return(&wording.GameRecord{
+			Game: wording.Game{AdminToken: "wretched-apostle", Token: "hungry-hippo", Answer: "potato", GuessLimit: 2, Disabled: true},
+			Players: []wording.PlayerRecord{
+				{PlayerToken: "player-one", Plays: wording.Plays{Attempts: []string{"tomato", "potato"}}},
+				{PlayerToken: "player-two", Plays: wording.Plays{Attempts: []string{"tomato"}}},
+			},
+		}, nil).
+		Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/games/wretched-apostle", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"The answer is <strong>potato</strong>",
+		"This game is disabled",
+		logging.HashToken("player-one"),
+		"tomato, potato</td><td>won",
+		"tomato</td><td>playing",
+		`action="/admin/games/wretched-apostle/enable"`,
+	} {
+		assert.Assert(t, strings.Contains(body, want), "missing %q", want)
+	}
+
+	// Player tokens are credentials, so operators only see hashes.
+	assert.Assert(t, !strings.Contains(body, "player-one"))
+}
+
+func TestAdminDisableGame(t *testing.T) {
+	svc := NewMockService(t)
+	admin := NewAdmin("http://localhost:8080", svc, StubAuth("alice"), logging.NewRecentErrors(1), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Use(admin.Authenticate)
+	router.Post("/admin/games/{admin_token}/disable", admin.DisableGame)
+
+	svc.EXPECT().
+		SetGameDisabled(mock.Anything, "alice", "wretched-apostle", true).
+		Return(nil).
+		Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/games/wretched-apostle/disable", nil))
+	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
+	assert.Equal(t, "/admin/games/wretched-apostle", w.Result().Header.Get("Location"))
+}
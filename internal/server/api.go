@@ -0,0 +1,222 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// APIPlayerIdentity is the API's counterpart to PlayerIdentity. Players
+// are identified by the signed token in the api.PlayerHeader request
+// header, and a new one is returned in the response header if the request
+// didn't carry a valid one. Nothing is read from cookies, so API routes
+// don't need CSRF protection.
+func (s *Server) APIPlayerIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token, err := s.signer.Verify(r.Header.Get(api.PlayerHeader))
+		if err != nil {
+			token = s.svc.NewPlayerToken(ctx)
+			w.Header().Set(api.PlayerHeader, s.signer.Sign(token))
+		}
+
+		next.ServeHTTP(w, r.WithContext(withPlayerToken(ctx, token)))
+	})
+}
+
+// APICreateGame creates a game and returns its links and difficulty.
+func (s *Server) APICreateGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.CreateGameRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, api.NewError(http.StatusBadRequest, "request body must be JSON"))
+		return
+	}
+
+	game, err := s.svc.CreateGame(ctx, req.Answer, req.GuessLimit, req.Settings(), playerToken(ctx))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, api.CreatedGame{
+		AdminToken: game.AdminToken,
+		Token:      game.Token,
+		AdminURL:   fmt.Sprintf("%s/manage/%s", s.baseURL, game.AdminToken),
+		PlayerURL:  fmt.Sprintf("%s/game/%s", s.baseURL, game.Token),
+		GuessLimit: game.GuessLimit,
+		Difficulty: api.FromDifficulty(game),
+	})
+}
+
+// APIGame returns the game and the player's progress.
+func (s *Server) APIGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	game, err := s.svc.GameByToken(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	s.writeAPIGame(w, r, game)
+}
+
+// APIGuess submits a guess and returns the player's updated progress.
+func (s *Server) APIGuess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.GuessRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, api.NewError(http.StatusBadRequest, "request body must be JSON"))
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+
+	err = s.svc.SubmitGuess(ctx, token, playerToken(ctx), req.Guess)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	game, err := s.svc.GameByToken(ctx, token)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	s.writeAPIGame(w, r, game)
+}
+
+// APIStats returns the lifetime stats along with a daily time series for
+// the last ?days, 30 by default.
+func (s *Server) APIStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days, err := statsDays(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, api.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	totals, err := s.svc.Stats(ctx)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	series, err := s.svc.DailyStats(ctx, days)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.FromStats(totals, series))
+}
+
+// APIGameStats is APIStats for a single game, identified by its admin
+// token, along with how its players have won and lost.
+func (s *Server) APIGameStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days, err := statsDays(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, api.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	adminToken := chi.URLParam(r, "admin_token")
+
+	stats, err := s.svc.GameStats(ctx, adminToken)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	series, err := s.svc.GameDailyStats(ctx, adminToken, days)
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.FromGameStats(stats, series))
+}
+
+// maxStatsDays is the longest time series that can be requested.
+const maxStatsDays = 366
+
+// statsDays reads how many days of stats were asked for.
+func statsDays(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("days")
+	if v == "" {
+		return 30, nil
+	}
+
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 1 || days > maxStatsDays {
+		return 0, fmt.Errorf("days must be a number from 1 to %d", maxStatsDays)
+	}
+
+	return days, nil
+}
+
+func (s *Server) writeAPIGame(w http.ResponseWriter, r *http.Request, game *wording.Game) {
+	ctx := r.Context()
+
+	state, err := s.svc.GameState(ctx, game.Token, playerToken(ctx))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	opensAt, closesAt := api.FromSchedule(game.GameSettings)
+
+	writeJSON(w, http.StatusOK, api.Game{
+		Token:      game.Token,
+		Length:     len(game.Answer),
+		GuessLimit: game.GuessLimit,
+		State:      api.FromGameState(state),
+		OpensAt:    opensAt,
+		ClosesAt:   closesAt,
+	})
+}
+
+// writeAPIError maps service errors to API errors.
+func (s *Server) writeAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	var violations wording.InputViolations
+
+	switch {
+	case errors.As(err, &violations):
+		writeJSON(w, http.StatusBadRequest, api.FromViolations(violations))
+	case errors.Is(err, service.ErrNotFound):
+		writeJSON(w, http.StatusNotFound, api.NewError(http.StatusNotFound, "game not found"))
+	case errors.Is(err, service.ErrGameDisabled):
+		writeJSON(w, http.StatusGone, api.NewError(http.StatusGone, err.Error()))
+	case errors.Is(err, service.ErrGuessLimitReached), errors.Is(err, service.ErrCannotContinue),
+		errors.Is(err, service.ErrGameNotOpen), errors.Is(err, service.ErrGameClosed):
+		writeJSON(w, http.StatusConflict, api.NewError(http.StatusConflict, err.Error()))
+	default:
+		logging.From(r.Context(), s.log).WithError(err).Error("handling API request")
+		writeJSON(w, http.StatusInternalServerError, api.NewError(http.StatusInternalServerError, ""))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func newAPITestServer(t *testing.T, svc Service) (*Server, *httptest.Server) {
+	svr := New("https://wording.example", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Route("/api", func(router chi.Router) {
+		router.Use(svr.APIPlayerIdentity)
+		router.Post("/games", svr.APICreateGame)
+		router.Get("/games/{token}", svr.APIGame)
+		router.Post("/games/{token}/guesses", svr.APIGuess)
+		router.Get("/manage/{admin_token}/stats", svr.APIGameStats)
+	})
+
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+
+	return svr, ts
+}
+
+func TestAPIPlayerIdentity(t *testing.T) {
+	svc := NewMockService(t)
+	svr, ts := newAPITestServer(t, svc)
+
+	game := &wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6}
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("fresh-player").Once()
+	svc.EXPECT().GameByToken(mock.Anything, "hungry-hippo").Return(game, nil)
+	svc.EXPECT().
+		GameState(mock.Anything, "hungry-hippo", "fresh-player").
+		Return(&wording.GameState{CanContinue: true}, nil).
+		Twice()
+
+	client := api.NewClient(ts.URL, "")
+
+	got, err := client.Game(context.Background(), "hungry-hippo")
+	assert.NilError(t, err)
+	assert.Equal(t, 6, got.Length)
+	assert.Equal(t, 6, got.GuessLimit)
+	assert.Equal(t, svr.signer.Sign("fresh-player"), client.PlayerToken())
+
+	// The issued token is sent back, so the same player is seen again.
+	_, err = client.Game(context.Background(), "hungry-hippo")
+	assert.NilError(t, err)
+}
+
+func TestAPIGuess(t *testing.T) {
+	game := &wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name: "accepted",
+		},
+		{
+			name:       "invalid guess",
+			err:        fmt.Errorf("invalid input: %w", wording.InputViolations{"guess": {errors.New("must be 6 letters")}}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "game over",
+			err:        service.ErrCannotContinue,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "no such game",
+			err:        service.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewMockService(t)
+			svr, ts := newAPITestServer(t, svc)
+
+			svc.EXPECT().
+				SubmitGuess(mock.Anything, "hungry-hippo", "returning-player", "tomato").
+				Return(tt.err).
+				Once()
+			if tt.err == nil {
+				svc.EXPECT().GameByToken(mock.Anything, "hungry-hippo").Return(game, nil).Once()
+				svc.EXPECT().
+					GameState(mock.Anything, "hungry-hippo", "returning-player").
+					Return((&wording.Plays{Attempts: []string{"tomato"}}).Evaluate("potato", 6), nil).
+					Once()
+			}
+
+			client := api.NewClient(ts.URL, svr.signer.Sign("returning-player"))
+
+			got, err := client.Guess(context.Background(), "hungry-hippo", "tomato")
+			if tt.wantStatus != 0 {
+				assert.Assert(t, api.IsStatus(err, tt.wantStatus), "got %v", err)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, 1, len(got.State.Attempts))
+			assert.Equal(t, api.Character{Value: "t", Partial: true}, got.State.Attempts[0][0])
+			assert.Equal(t, api.Character{Value: "o", Correct: true}, got.State.Attempts[0][1])
+		})
+	}
+}
+
+func TestAPICreateGame(t *testing.T) {
+	svc := NewMockService(t)
+	_, ts := newAPITestServer(t, svc)
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("fresh-player").Once()
+	svc.EXPECT().
+		CreateGame(mock.Anything, "jazzy", 3, wording.GameSettings{}, "fresh-player").
+		Return(&wording.Game{
+			AdminToken: "wretched-apostle",
+			Token:      "hungry-hippo",
+			Answer:     "jazzy",
+			GuessLimit: 3,
+			Difficulty: &wording.Difficulty{Score: 80, SolverGuesses: 5},
+		}, nil).
+		Once()
+
+	got, err := api.NewClient(ts.URL, "").CreateGame(context.Background(), "jazzy", 3)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, &api.CreatedGame{
+		AdminToken: "wretched-apostle",
+		Token:      "hungry-hippo",
+		AdminURL:   "https://wording.example/manage/wretched-apostle",
+		PlayerURL:  "https://wording.example/game/hungry-hippo",
+		GuessLimit: 3,
+		Difficulty: &api.Difficulty{
+			Score:           80,
+			Label:           "brutal",
+			SolverGuesses:   5,
+			ExpectedGuesses: 6,
+			LimitTooLow:     true,
+		},
+	}, got)
+}
+
+func TestAPICreateGameRejectsEmptyAnswer(t *testing.T) {
+	svc := NewMockService(t)
+	_, ts := newAPITestServer(t, svc)
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("fresh-player").Once()
+	svc.EXPECT().
+		CreateGame(mock.Anything, "", 3, wording.GameSettings{}, "fresh-player").
+		Return(nil, fmt.Errorf("invalid input: %w", wording.ValidateAnswer(""))).
+		Once()
+
+	_, err := api.NewClient(ts.URL, "").CreateGame(context.Background(), "", 3)
+	assert.Assert(t, api.IsStatus(err, http.StatusBadRequest), "got %v", err)
+}
+
+func TestAPIGameStats(t *testing.T) {
+	svc := NewMockService(t)
+	_, ts := newAPITestServer(t, svc)
+
+	day := time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("player")
+	svc.EXPECT().
+		GameStats(mock.Anything, "wretched-apostle").
+		Return(wording.GameStats{
+			Stats:          wording.Stats{GuessesMade: 7, GamesWon: 2},
+			PlayersStarted: 3,
+			WinsIn:         []int{0, 1, 1},
+			FirstGuesses:   []wording.WordCount{{Word: "tomato", Count: 2}},
+		}, nil).
+		Once()
+	svc.EXPECT().
+		GameDailyStats(mock.Anything, "wretched-apostle", 2).
+		Return([]wording.DailyStats{
+			{Day: day.AddDate(0, 0, -1)},
+			{Day: day, PlayersStarted: 3, GuessesMade: 7, GamesWon: 2, WinsIn: []int{0, 1, 1}},
+		}, nil).
+		Once()
+	svc.EXPECT().
+		GameStats(mock.Anything, "nope").
+		Return(wording.GameStats{}, service.ErrNotFound).
+		Once()
+
+	client := api.NewClient(ts.URL, "")
+
+	got, err := client.GameStats(context.Background(), "wretched-apostle", 2)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &api.Stats{
+		GuessesMade: 7,
+		GamesWon:    2,
+		Game: &api.GameSummary{
+			PlayersStarted:    3,
+			WinsIn:            []int{0, 1, 1},
+			FirstGuesses:      []api.WordCount{{Word: "tomato", Count: 2}},
+			WrongFinalGuesses: []api.WordCount{},
+		},
+		Days: []api.Day{
+			{Date: "2023-01-01", WinsIn: []int{}},
+			{Date: "2023-01-02", PlayersStarted: 3, GuessesMade: 7, GamesWon: 2, WinsIn: []int{0, 1, 1}},
+		},
+	}, got)
+
+	_, err = client.GameStats(context.Background(), "nope", 2)
+	assert.Assert(t, api.IsStatus(err, http.StatusNotFound), err)
+
+	_, err = client.GameStats(context.Background(), "wretched-apostle", 1000)
+	assert.Assert(t, api.IsStatus(err, http.StatusBadRequest), err)
+}
+
+func TestAPIMe(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("https://wording.example", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Route("/api", func(router chi.Router) {
+		router.Use(svr.APIPlayerIdentity)
+		router.Get("/me", svr.APIMe)
+		router.Get("/me/history", svr.APIHistory)
+		router.Delete("/me/history", svr.APIClearHistory)
+	})
+
+	started := time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	svc.EXPECT().
+		PlayerStats(mock.Anything, "player").
+		Return(wording.PlayerStats{GamesPlayed: 2, GamesFinished: 1, GamesWon: 1, CurrentStreak: 1, MaxStreak: 1, WinsIn: []int{0, 1}}, nil).
+		Once()
+	svc.EXPECT().
+		PlayerHistory(mock.Anything, "player").
+		Return([]wording.PlayerGame{
+			{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6, StartedAt: started, Plays: wording.Plays{Attempts: []string{"tomato", "potato"}}},
+			{Token: "sleepy-sloth", GuessLimit: 6, StartedAt: started, Plays: wording.Plays{Attempts: []string{"tomato"}}},
+		}, nil).
+		Once()
+	svc.EXPECT().ClearPlayerHistory(mock.Anything, "player").Return(2, nil).Once()
+
+	get := func(method, path string, out any) {
+		t.Helper()
+
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set(api.PlayerHeader, svr.signer.Sign("player"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body)
+		assert.NilError(t, json.NewDecoder(w.Body).Decode(out))
+	}
+
+	var stats api.PlayerStats
+	get("GET", "/api/me", &stats)
+	assert.DeepEqual(t, api.PlayerStats{
+		GamesPlayed:   2,
+		GamesFinished: 1,
+		GamesWon:      1,
+		WinRate:       100,
+		CurrentStreak: 1,
+		MaxStreak:     1,
+		WinsIn:        []int{0, 1},
+	}, stats)
+
+	var history api.PlayerHistory
+	get("GET", "/api/me/history", &history)
+	assert.DeepEqual(t, api.PlayerHistory{Games: []api.PlayedGame{
+		{Token: "hungry-hippo", StartedAt: started, GuessLimit: 6, Guesses: []string{"tomato", "potato"}, Won: true, Finished: true, Answer: "potato"},
+		{Token: "sleepy-sloth", StartedAt: started, GuessLimit: 6, Guesses: []string{"tomato"}},
+	}}, history)
+
+	var cleared api.ClearedHistory
+	get("DELETE", "/api/me/history", &cleared)
+	assert.Equal(t, 2, cleared.Games)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/view"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// MyGames lists the games the player created, a page at a time.
+func (s *Server) MyGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, http.StatusText(http.StatusBadRequest)+" page must be a positive number", http.StatusBadRequest)
+			return
+		}
+		page = n
+	}
+
+	created, err := s.svc.CreatedGames(ctx, playerToken(ctx), page)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	myGames := view.MyGames{
+		CSRFToken:    csrfToken(ctx),
+		BaseURL:      s.baseURL,
+		LoggedIn:     account(ctx) != nil,
+		LoginEnabled: s.svc.MailEnabled(),
+		Games:        createdGamesView(created.Games),
+		Page:         created.Page,
+	}
+	if created.Page > 1 {
+		myGames.PrevPage = created.Page - 1
+	}
+	if created.More {
+		myGames.NextPage = created.Page + 1
+	}
+
+	if v := r.URL.Query().Get("deleted"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			myGames.Deleted = &n
+		}
+	}
+
+	err = myGames.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteMyGames deletes the games ticked on the player's list. Only games
+// the player created are deleted.
+func (s *Server) DeleteMyGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_ = r.ParseForm()
+
+	n, err := s.svc.DeleteCreatedGames(ctx, playerToken(ctx), r.PostForm["admin_token"])
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/my-games?deleted="+strconv.Itoa(n), http.StatusSeeOther)
+}
+
+func createdGamesView(games []wording.CreatedGame) []view.CreatedGame {
+	v := make([]view.CreatedGame, len(games))
+	for i := range games {
+		g := &games[i]
+		v[i] = view.CreatedGame{
+			AdminToken: g.AdminToken,
+			Token:      g.Token,
+			Answer:     g.Answer,
+			Date:       g.CreatedAt.UTC().Format(api.DateLayout),
+			Players:    g.Players,
+			WinRate:    g.WinRate(),
+		}
+	}
+	return v
+}
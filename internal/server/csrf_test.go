@@ -0,0 +1,138 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func newCSRFTestRouter(svr *Server) http.Handler {
+	router := chi.NewRouter()
+	router.Use(svr.PlayerIdentity)
+	router.Use(svr.CSRF)
+	router.Get("/", svr.Home)
+	router.Post("/games", svr.CreateGame)
+	router.Post("/game/{token}", svr.Guess)
+	router.Post("/manage/{admin_token}/delete", svr.DeleteGame)
+	return router
+}
+
+func TestCSRFTokenIsRendered(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("player")
+	svc.EXPECT().Stats(mock.Anything).Return(wording.Stats{}, nil)
+
+	w := httptest.NewRecorder()
+	newCSRFTestRouter(svr).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+	var token string
+	for _, c := range w.Result().Cookies() {
+		if c.Name == csrfCookie {
+			token = c.Value
+		}
+	}
+	assert.Assert(t, token != "")
+	assert.Assert(t, strings.Contains(w.Body.String(), `name="csrf_token" value="`+token+`"`), w.Body)
+}
+
+func TestCSRFRejectsForgedRequests(t *testing.T) {
+	routes := []struct {
+		path string
+		form url.Values
+	}{
+		{path: "/games", form: url.Values{"answer": {"potato"}, "num_attempts": {"6"}}},
+		{path: "/game/hungry-hippo", form: url.Values{"guess": {"tomato"}}},
+		{path: "/manage/wretched-apostle/delete", form: url.Values{}},
+	}
+
+	forgeries := []struct {
+		name   string
+		cookie func(s *Server) string
+		field  func(s *Server) string
+	}{
+		{
+			name: "no token at all",
+		},
+		{
+			name:   "cookie without form field",
+			cookie: func(s *Server) string { return s.signer.Sign("nonce") },
+		},
+		{
+			name:  "form field without cookie",
+			field: func(s *Server) string { return s.signer.Sign("nonce") },
+		},
+		{
+			name:   "mismatched token",
+			cookie: func(s *Server) string { return s.signer.Sign("nonce") },
+			field:  func(s *Server) string { return s.signer.Sign("other-nonce") },
+		},
+		{
+			name:   "matching but unsigned token",
+			cookie: func(*Server) string { return "nonce" },
+			field:  func(*Server) string { return "nonce" },
+		},
+	}
+
+	for _, route := range routes {
+		for _, forgery := range forgeries {
+			t.Run(route.path+"/"+forgery.name, func(t *testing.T) {
+				// The mock has no expectations for state-changing calls, so
+				// the test fails if a forged request reaches the service.
+				svc := NewMockService(t)
+				svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+				svc.EXPECT().NewPlayerToken(mock.Anything).Return("player")
+
+				form := url.Values{}
+				for k, v := range route.form {
+					form[k] = v
+				}
+				if forgery.field != nil {
+					form.Set(csrfField, forgery.field(svr))
+				}
+
+				r := httptest.NewRequest("POST", route.path, strings.NewReader(form.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				if forgery.cookie != nil {
+					r.AddCookie(&http.Cookie{Name: csrfCookie, Value: forgery.cookie(svr)})
+				}
+
+				w := httptest.NewRecorder()
+				newCSRFTestRouter(svr).ServeHTTP(w, r)
+
+				assert.Equal(t, http.StatusForbidden, w.Code, w.Body)
+			})
+		}
+	}
+}
+
+func TestCSRFAcceptsMatchingToken(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("player")
+	svc.EXPECT().DeleteGame(mock.Anything, "wretched-apostle").Return(nil).Once()
+
+	token := svr.signer.Sign("nonce")
+	form := url.Values{csrfField: {token}}
+
+	r := httptest.NewRequest("POST", "/manage/wretched-apostle/delete", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+
+	w := httptest.NewRecorder()
+	newCSRFTestRouter(svr).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/view"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// Me renders the player's own stats and history.
+func (s *Server) Me(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := playerToken(ctx)
+
+	stats, err := s.svc.PlayerStats(ctx, id)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	games, err := s.svc.PlayerHistory(ctx, id)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	me := view.Me{
+		CSRFToken:     csrfToken(ctx),
+		GamesPlayed:   stats.GamesPlayed,
+		GamesWon:      stats.GamesWon,
+		WinRate:       stats.WinRate(),
+		CurrentStreak: stats.CurrentStreak,
+		MaxStreak:     stats.MaxStreak,
+		Distribution:  winsInView(stats.WinsIn),
+		Games:         playedGamesView(games),
+	}
+
+	if v := r.URL.Query().Get("cleared"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			me.Cleared = &n
+		}
+	}
+
+	err = me.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ClearHistory forgets the player's guesses.
+func (s *Server) ClearHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	n, err := s.svc.ClearPlayerHistory(ctx, playerToken(ctx))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/me?cleared="+strconv.Itoa(n), http.StatusSeeOther)
+}
+
+// ExportHistory downloads the player's history as JSON.
+func (s *Server) ExportHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	games, err := s.svc.PlayerHistory(ctx, playerToken(ctx))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="wording-history.json"`)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(api.FromPlayerHistory(games))
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Warn("writing player history")
+	}
+}
+
+// APIMe returns the player's stats.
+func (s *Server) APIMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats, err := s.svc.PlayerStats(ctx, playerToken(ctx))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.FromPlayerStats(stats))
+}
+
+// APIHistory returns every game the player has played.
+func (s *Server) APIHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	games, err := s.svc.PlayerHistory(ctx, playerToken(ctx))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.FromPlayerHistory(games))
+}
+
+// APIClearHistory forgets the player's guesses.
+func (s *Server) APIClearHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	n, err := s.svc.ClearPlayerHistory(ctx, playerToken(ctx))
+	if err != nil {
+		s.writeAPIError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.ClearedHistory{Games: n})
+}
+
+func winsInView(winsIn []int) []view.Bar {
+	labels := make([]string, len(winsIn))
+	for i := range winsIn {
+		labels[i] = strconv.Itoa(i + 1)
+	}
+
+	return view.Bars(labels, winsIn)
+}
+
+// playedGamesView lists the player's games, most recent first.
+func playedGamesView(games []wording.PlayerGame) []view.PlayedGame {
+	v := make([]view.PlayedGame, len(games))
+	for i := range games {
+		g := &games[i]
+
+		result := "playing"
+		switch {
+		case g.Won():
+			result = "won"
+		case g.Finished():
+			result = "lost"
+		}
+
+		v[len(games)-1-i] = view.PlayedGame{
+			Token:   g.Token,
+			Date:    g.StartedAt.UTC().Format(api.DateLayout),
+			Guesses: len(g.Attempts),
+			Limit:   g.GuessLimit,
+			Result:  result,
+		}
+	}
+	return v
+}
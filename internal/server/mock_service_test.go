@@ -22,13 +22,105 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
-// CreateGame provides a mock function with given fields: ctx, answer, guessLimit
-func (_m *MockService) CreateGame(ctx context.Context, answer string, guessLimit int) (*wording.Game, error) {
-	ret := _m.Called(ctx, answer, guessLimit)
+// AuditLog provides a mock function with given fields: ctx, limit
+func (_m *MockService) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []wording.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int) []wording.AuditEntry); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_AuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditLog'
+type MockService_AuditLog_Call struct {
+	*mock.Call
+}
+
+// AuditLog is a helper method to define mock.On call
+//  - ctx context.Context
+//  - limit int
+func (_e *MockService_Expecter) AuditLog(ctx interface{}, limit interface{}) *MockService_AuditLog_Call {
+	return &MockService_AuditLog_Call{Call: _e.mock.On("AuditLog", ctx, limit)}
+}
+
+func (_c *MockService_AuditLog_Call) Run(run func(ctx context.Context, limit int)) *MockService_AuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_AuditLog_Call) Return(_a0 []wording.AuditEntry, _a1 error) *MockService_AuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ClearPlayerHistory provides a mock function with given fields: ctx, playerToken
+func (_m *MockService) ClearPlayerHistory(ctx context.Context, playerToken string) (int, error) {
+	ret := _m.Called(ctx, playerToken)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, playerToken)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ClearPlayerHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearPlayerHistory'
+type MockService_ClearPlayerHistory_Call struct {
+	*mock.Call
+}
+
+// ClearPlayerHistory is a helper method to define mock.On call
+//  - ctx context.Context
+//  - playerToken string
+func (_e *MockService_Expecter) ClearPlayerHistory(ctx interface{}, playerToken interface{}) *MockService_ClearPlayerHistory_Call {
+	return &MockService_ClearPlayerHistory_Call{Call: _e.mock.On("ClearPlayerHistory", ctx, playerToken)}
+}
+
+func (_c *MockService_ClearPlayerHistory_Call) Run(run func(ctx context.Context, playerToken string)) *MockService_ClearPlayerHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ClearPlayerHistory_Call) Return(_a0 int, _a1 error) *MockService_ClearPlayerHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// CreateGame provides a mock function with given fields: ctx, answer, guessLimit, settings, creator
+func (_m *MockService) CreateGame(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	ret := _m.Called(ctx, answer, guessLimit, settings, creator)
 
 	var r0 *wording.Game
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) *wording.Game); ok {
-		r0 = rf(ctx, answer, guessLimit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, wording.GameSettings, string) *wording.Game); ok {
+		r0 = rf(ctx, answer, guessLimit, settings, creator)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*wording.Game)
@@ -36,8 +128,8 @@ func (_m *MockService) CreateGame(ctx context.Context, answer string, guessLimit
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, answer, guessLimit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, wording.GameSettings, string) error); ok {
+		r1 = rf(ctx, answer, guessLimit, settings, creator)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,13 +146,15 @@ type MockService_CreateGame_Call struct {
 //  - ctx context.Context
 //  - answer string
 //  - guessLimit int
-func (_e *MockService_Expecter) CreateGame(ctx interface{}, answer interface{}, guessLimit interface{}) *MockService_CreateGame_Call {
-	return &MockService_CreateGame_Call{Call: _e.mock.On("CreateGame", ctx, answer, guessLimit)}
+//  - settings wording.GameSettings
+//  - creator string
+func (_e *MockService_Expecter) CreateGame(ctx interface{}, answer interface{}, guessLimit interface{}, settings interface{}, creator interface{}) *MockService_CreateGame_Call {
+	return &MockService_CreateGame_Call{Call: _e.mock.On("CreateGame", ctx, answer, guessLimit, settings, creator)}
 }
 
-func (_c *MockService_CreateGame_Call) Run(run func(ctx context.Context, answer string, guessLimit int)) *MockService_CreateGame_Call {
+func (_c *MockService_CreateGame_Call) Run(run func(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string)) *MockService_CreateGame_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(wording.GameSettings), args[4].(string))
 	})
 	return _c
 }
@@ -70,6 +164,147 @@ func (_c *MockService_CreateGame_Call) Return(_a0 *wording.Game, _a1 error) *Moc
 	return _c
 }
 
+// CreatedGames provides a mock function with given fields: ctx, creator, page
+func (_m *MockService) CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error) {
+	ret := _m.Called(ctx, creator, page)
+
+	var r0 *wording.CreatedGames
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *wording.CreatedGames); ok {
+		r0 = rf(ctx, creator, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.CreatedGames)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, creator, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreatedGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatedGames'
+type MockService_CreatedGames_Call struct {
+	*mock.Call
+}
+
+// CreatedGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - creator string
+//  - page int
+func (_e *MockService_Expecter) CreatedGames(ctx interface{}, creator interface{}, page interface{}) *MockService_CreatedGames_Call {
+	return &MockService_CreatedGames_Call{Call: _e.mock.On("CreatedGames", ctx, creator, page)}
+}
+
+func (_c *MockService_CreatedGames_Call) Run(run func(ctx context.Context, creator string, page int)) *MockService_CreatedGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_CreatedGames_Call) Return(_a0 *wording.CreatedGames, _a1 error) *MockService_CreatedGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// DailyStats provides a mock function with given fields: ctx, days
+func (_m *MockService) DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error) {
+	ret := _m.Called(ctx, days)
+
+	var r0 []wording.DailyStats
+	if rf, ok := ret.Get(0).(func(context.Context, int) []wording.DailyStats); ok {
+		r0 = rf(ctx, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.DailyStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_DailyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DailyStats'
+type MockService_DailyStats_Call struct {
+	*mock.Call
+}
+
+// DailyStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - days int
+func (_e *MockService_Expecter) DailyStats(ctx interface{}, days interface{}) *MockService_DailyStats_Call {
+	return &MockService_DailyStats_Call{Call: _e.mock.On("DailyStats", ctx, days)}
+}
+
+func (_c *MockService_DailyStats_Call) Run(run func(ctx context.Context, days int)) *MockService_DailyStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_DailyStats_Call) Return(_a0 []wording.DailyStats, _a1 error) *MockService_DailyStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// DeleteCreatedGames provides a mock function with given fields: ctx, creator, adminTokens
+func (_m *MockService) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	ret := _m.Called(ctx, creator, adminTokens)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) int); ok {
+		r0 = rf(ctx, creator, adminTokens)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, creator, adminTokens)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_DeleteCreatedGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCreatedGames'
+type MockService_DeleteCreatedGames_Call struct {
+	*mock.Call
+}
+
+// DeleteCreatedGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - creator string
+//  - adminTokens []string
+func (_e *MockService_Expecter) DeleteCreatedGames(ctx interface{}, creator interface{}, adminTokens interface{}) *MockService_DeleteCreatedGames_Call {
+	return &MockService_DeleteCreatedGames_Call{Call: _e.mock.On("DeleteCreatedGames", ctx, creator, adminTokens)}
+}
+
+func (_c *MockService_DeleteCreatedGames_Call) Run(run func(ctx context.Context, creator string, adminTokens []string)) *MockService_DeleteCreatedGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteCreatedGames_Call) Return(_a0 int, _a1 error) *MockService_DeleteCreatedGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // DeleteGame provides a mock function with given fields: ctx, adminToken
 func (_m *MockService) DeleteGame(ctx context.Context, adminToken string) error {
 	ret := _m.Called(ctx, adminToken)
@@ -108,6 +343,53 @@ func (_c *MockService_DeleteGame_Call) Return(_a0 error) *MockService_DeleteGame
 	return _c
 }
 
+// ExportGame provides a mock function with given fields: ctx, adminToken
+func (_m *MockService) ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	ret := _m.Called(ctx, adminToken)
+
+	var r0 *wording.GameRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.GameRecord); ok {
+		r0 = rf(ctx, adminToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.GameRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ExportGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportGame'
+type MockService_ExportGame_Call struct {
+	*mock.Call
+}
+
+// ExportGame is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+func (_e *MockService_Expecter) ExportGame(ctx interface{}, adminToken interface{}) *MockService_ExportGame_Call {
+	return &MockService_ExportGame_Call{Call: _e.mock.On("ExportGame", ctx, adminToken)}
+}
+
+func (_c *MockService_ExportGame_Call) Run(run func(ctx context.Context, adminToken string)) *MockService_ExportGame_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ExportGame_Call) Return(_a0 *wording.GameRecord, _a1 error) *MockService_ExportGame_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Game provides a mock function with given fields: ctx, adminToken
 func (_m *MockService) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
 	ret := _m.Called(ctx, adminToken)
@@ -202,6 +484,54 @@ func (_c *MockService_GameByToken_Call) Return(_a0 *wording.Game, _a1 error) *Mo
 	return _c
 }
 
+// GameDailyStats provides a mock function with given fields: ctx, adminToken, days
+func (_m *MockService) GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error) {
+	ret := _m.Called(ctx, adminToken, days)
+
+	var r0 []wording.DailyStats
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []wording.DailyStats); ok {
+		r0 = rf(ctx, adminToken, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.DailyStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, adminToken, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GameDailyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameDailyStats'
+type MockService_GameDailyStats_Call struct {
+	*mock.Call
+}
+
+// GameDailyStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - days int
+func (_e *MockService_Expecter) GameDailyStats(ctx interface{}, adminToken interface{}, days interface{}) *MockService_GameDailyStats_Call {
+	return &MockService_GameDailyStats_Call{Call: _e.mock.On("GameDailyStats", ctx, adminToken, days)}
+}
+
+func (_c *MockService_GameDailyStats_Call) Run(run func(ctx context.Context, adminToken string, days int)) *MockService_GameDailyStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_GameDailyStats_Call) Return(_a0 []wording.DailyStats, _a1 error) *MockService_GameDailyStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GameState provides a mock function with given fields: ctx, gameToken, playerToken
 func (_m *MockService) GameState(ctx context.Context, gameToken string, playerToken string) (*wording.GameState, error) {
 	ret := _m.Called(ctx, gameToken, playerToken)
@@ -251,14 +581,14 @@ func (_c *MockService_GameState_Call) Return(_a0 *wording.GameState, _a1 error)
 }
 
 // GameStats provides a mock function with given fields: ctx, adminToken
-func (_m *MockService) GameStats(ctx context.Context, adminToken string) (wording.Stats, error) {
+func (_m *MockService) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
 	ret := _m.Called(ctx, adminToken)
 
-	var r0 wording.Stats
-	if rf, ok := ret.Get(0).(func(context.Context, string) wording.Stats); ok {
+	var r0 wording.GameStats
+	if rf, ok := ret.Get(0).(func(context.Context, string) wording.GameStats); ok {
 		r0 = rf(ctx, adminToken)
 	} else {
-		r0 = ret.Get(0).(wording.Stats)
+		r0 = ret.Get(0).(wording.GameStats)
 	}
 
 	var r1 error
@@ -290,61 +620,751 @@ func (_c *MockService_GameStats_Call) Run(run func(ctx context.Context, adminTok
 	return _c
 }
 
-func (_c *MockService_GameStats_Call) Return(_a0 wording.Stats, _a1 error) *MockService_GameStats_Call {
+func (_c *MockService_GameStats_Call) Return(_a0 wording.GameStats, _a1 error) *MockService_GameStats_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// NewPlayerToken provides a mock function with given fields: ctx
-func (_m *MockService) NewPlayerToken(ctx context.Context) string {
-	ret := _m.Called(ctx)
+// InspectGame provides a mock function with given fields: ctx, operator, adminToken
+func (_m *MockService) InspectGame(ctx context.Context, operator string, adminToken string) (*wording.GameRecord, error) {
+	ret := _m.Called(ctx, operator, adminToken)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
-		r0 = rf(ctx)
+	var r0 *wording.GameRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *wording.GameRecord); ok {
+		r0 = rf(ctx, operator, adminToken)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.GameRecord)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, operator, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockService_NewPlayerToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewPlayerToken'
-type MockService_NewPlayerToken_Call struct {
+// MockService_InspectGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectGame'
+type MockService_InspectGame_Call struct {
 	*mock.Call
 }
 
-// NewPlayerToken is a helper method to define mock.On call
+// InspectGame is a helper method to define mock.On call
 //  - ctx context.Context
-func (_e *MockService_Expecter) NewPlayerToken(ctx interface{}) *MockService_NewPlayerToken_Call {
-	return &MockService_NewPlayerToken_Call{Call: _e.mock.On("NewPlayerToken", ctx)}
+//  - operator string
+//  - adminToken string
+func (_e *MockService_Expecter) InspectGame(ctx interface{}, operator interface{}, adminToken interface{}) *MockService_InspectGame_Call {
+	return &MockService_InspectGame_Call{Call: _e.mock.On("InspectGame", ctx, operator, adminToken)}
 }
 
-func (_c *MockService_NewPlayerToken_Call) Run(run func(ctx context.Context)) *MockService_NewPlayerToken_Call {
+func (_c *MockService_InspectGame_Call) Run(run func(ctx context.Context, operator string, adminToken string)) *MockService_InspectGame_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockService_NewPlayerToken_Call) Return(_a0 string) *MockService_NewPlayerToken_Call {
-	_c.Call.Return(_a0)
+func (_c *MockService_InspectGame_Call) Return(_a0 *wording.GameRecord, _a1 error) *MockService_InspectGame_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// Stats provides a mock function with given fields: ctx
-func (_m *MockService) Stats(ctx context.Context) (wording.Stats, error) {
-	ret := _m.Called(ctx)
+// Leaderboard provides a mock function with given fields: ctx, gameToken
+func (_m *MockService) Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error) {
+	ret := _m.Called(ctx, gameToken)
 
-	var r0 wording.Stats
-	if rf, ok := ret.Get(0).(func(context.Context) wording.Stats); ok {
-		r0 = rf(ctx)
+	var r0 []wording.Standing
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wording.Standing); ok {
+		r0 = rf(ctx, gameToken)
 	} else {
-		r0 = ret.Get(0).(wording.Stats)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.Standing)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, gameToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Leaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Leaderboard'
+type MockService_Leaderboard_Call struct {
+	*mock.Call
+}
+
+// Leaderboard is a helper method to define mock.On call
+//  - ctx context.Context
+//  - gameToken string
+func (_e *MockService_Expecter) Leaderboard(ctx interface{}, gameToken interface{}) *MockService_Leaderboard_Call {
+	return &MockService_Leaderboard_Call{Call: _e.mock.On("Leaderboard", ctx, gameToken)}
+}
+
+func (_c *MockService_Leaderboard_Call) Run(run func(ctx context.Context, gameToken string)) *MockService_Leaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Leaderboard_Call) Return(_a0 []wording.Standing, _a1 error) *MockService_Leaderboard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, loginToken, playerToken
+func (_m *MockService) Login(ctx context.Context, loginToken string, playerToken string) (*wording.Session, error) {
+	ret := _m.Called(ctx, loginToken, playerToken)
+
+	var r0 *wording.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *wording.Session); ok {
+		r0 = rf(ctx, loginToken, playerToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, loginToken, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockService_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//  - ctx context.Context
+//  - loginToken string
+//  - playerToken string
+func (_e *MockService_Expecter) Login(ctx interface{}, loginToken interface{}, playerToken interface{}) *MockService_Login_Call {
+	return &MockService_Login_Call{Call: _e.mock.On("Login", ctx, loginToken, playerToken)}
+}
+
+func (_c *MockService_Login_Call) Run(run func(ctx context.Context, loginToken string, playerToken string)) *MockService_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Login_Call) Return(_a0 *wording.Session, _a1 error) *MockService_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Logout provides a mock function with given fields: ctx, sessionToken
+func (_m *MockService) Logout(ctx context.Context, sessionToken string) error {
+	ret := _m.Called(ctx, sessionToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sessionToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sessionToken string
+func (_e *MockService_Expecter) Logout(ctx interface{}, sessionToken interface{}) *MockService_Logout_Call {
+	return &MockService_Logout_Call{Call: _e.mock.On("Logout", ctx, sessionToken)}
+}
+
+func (_c *MockService_Logout_Call) Run(run func(ctx context.Context, sessionToken string)) *MockService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Logout_Call) Return(_a0 error) *MockService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// MailEnabled provides a mock function with given fields:
+func (_m *MockService) MailEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockService_MailEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MailEnabled'
+type MockService_MailEnabled_Call struct {
+	*mock.Call
+}
+
+// MailEnabled is a helper method to define mock.On call
+func (_e *MockService_Expecter) MailEnabled() *MockService_MailEnabled_Call {
+	return &MockService_MailEnabled_Call{Call: _e.mock.On("MailEnabled")}
+}
+
+func (_c *MockService_MailEnabled_Call) Run(run func()) *MockService_MailEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_MailEnabled_Call) Return(_a0 bool) *MockService_MailEnabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// NewPlayerToken provides a mock function with given fields: ctx
+func (_m *MockService) NewPlayerToken(ctx context.Context) string {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockService_NewPlayerToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewPlayerToken'
+type MockService_NewPlayerToken_Call struct {
+	*mock.Call
+}
+
+// NewPlayerToken is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *MockService_Expecter) NewPlayerToken(ctx interface{}) *MockService_NewPlayerToken_Call {
+	return &MockService_NewPlayerToken_Call{Call: _e.mock.On("NewPlayerToken", ctx)}
+}
+
+func (_c *MockService_NewPlayerToken_Call) Run(run func(ctx context.Context)) *MockService_NewPlayerToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockService_NewPlayerToken_Call) Return(_a0 string) *MockService_NewPlayerToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// OperatorDeleteGame provides a mock function with given fields: ctx, operator, adminToken
+func (_m *MockService) OperatorDeleteGame(ctx context.Context, operator string, adminToken string) error {
+	ret := _m.Called(ctx, operator, adminToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, operator, adminToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_OperatorDeleteGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OperatorDeleteGame'
+type MockService_OperatorDeleteGame_Call struct {
+	*mock.Call
+}
+
+// OperatorDeleteGame is a helper method to define mock.On call
+//  - ctx context.Context
+//  - operator string
+//  - adminToken string
+func (_e *MockService_Expecter) OperatorDeleteGame(ctx interface{}, operator interface{}, adminToken interface{}) *MockService_OperatorDeleteGame_Call {
+	return &MockService_OperatorDeleteGame_Call{Call: _e.mock.On("OperatorDeleteGame", ctx, operator, adminToken)}
+}
+
+func (_c *MockService_OperatorDeleteGame_Call) Run(run func(ctx context.Context, operator string, adminToken string)) *MockService_OperatorDeleteGame_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_OperatorDeleteGame_Call) Return(_a0 error) *MockService_OperatorDeleteGame_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// PlayerHistory provides a mock function with given fields: ctx, playerToken
+func (_m *MockService) PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	ret := _m.Called(ctx, playerToken)
+
+	var r0 []wording.PlayerGame
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wording.PlayerGame); ok {
+		r0 = rf(ctx, playerToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.PlayerGame)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_PlayerHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlayerHistory'
+type MockService_PlayerHistory_Call struct {
+	*mock.Call
+}
+
+// PlayerHistory is a helper method to define mock.On call
+//  - ctx context.Context
+//  - playerToken string
+func (_e *MockService_Expecter) PlayerHistory(ctx interface{}, playerToken interface{}) *MockService_PlayerHistory_Call {
+	return &MockService_PlayerHistory_Call{Call: _e.mock.On("PlayerHistory", ctx, playerToken)}
+}
+
+func (_c *MockService_PlayerHistory_Call) Run(run func(ctx context.Context, playerToken string)) *MockService_PlayerHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_PlayerHistory_Call) Return(_a0 []wording.PlayerGame, _a1 error) *MockService_PlayerHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// PlayerStats provides a mock function with given fields: ctx, playerToken
+func (_m *MockService) PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error) {
+	ret := _m.Called(ctx, playerToken)
+
+	var r0 wording.PlayerStats
+	if rf, ok := ret.Get(0).(func(context.Context, string) wording.PlayerStats); ok {
+		r0 = rf(ctx, playerToken)
+	} else {
+		r0 = ret.Get(0).(wording.PlayerStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_PlayerStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlayerStats'
+type MockService_PlayerStats_Call struct {
+	*mock.Call
+}
+
+// PlayerStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - playerToken string
+func (_e *MockService_Expecter) PlayerStats(ctx interface{}, playerToken interface{}) *MockService_PlayerStats_Call {
+	return &MockService_PlayerStats_Call{Call: _e.mock.On("PlayerStats", ctx, playerToken)}
+}
+
+func (_c *MockService_PlayerStats_Call) Run(run func(ctx context.Context, playerToken string)) *MockService_PlayerStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_PlayerStats_Call) Return(_a0 wording.PlayerStats, _a1 error) *MockService_PlayerStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RecoverGames provides a mock function with given fields: ctx, recoveryToken
+func (_m *MockService) RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error) {
+	ret := _m.Called(ctx, recoveryToken)
+
+	var r0 []wording.Game
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wording.Game); ok {
+		r0 = rf(ctx, recoveryToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.Game)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, recoveryToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RecoverGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverGames'
+type MockService_RecoverGames_Call struct {
+	*mock.Call
+}
+
+// RecoverGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - recoveryToken string
+func (_e *MockService_Expecter) RecoverGames(ctx interface{}, recoveryToken interface{}) *MockService_RecoverGames_Call {
+	return &MockService_RecoverGames_Call{Call: _e.mock.On("RecoverGames", ctx, recoveryToken)}
+}
+
+func (_c *MockService_RecoverGames_Call) Run(run func(ctx context.Context, recoveryToken string)) *MockService_RecoverGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RecoverGames_Call) Return(_a0 []wording.Game, _a1 error) *MockService_RecoverGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RequestLogin provides a mock function with given fields: ctx, email
+func (_m *MockService) RequestLogin(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RequestLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestLogin'
+type MockService_RequestLogin_Call struct {
+	*mock.Call
+}
+
+// RequestLogin is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+func (_e *MockService_Expecter) RequestLogin(ctx interface{}, email interface{}) *MockService_RequestLogin_Call {
+	return &MockService_RequestLogin_Call{Call: _e.mock.On("RequestLogin", ctx, email)}
+}
+
+func (_c *MockService_RequestLogin_Call) Run(run func(ctx context.Context, email string)) *MockService_RequestLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RequestLogin_Call) Return(_a0 error) *MockService_RequestLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// RequestRecovery provides a mock function with given fields: ctx, email
+func (_m *MockService) RequestRecovery(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RequestRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestRecovery'
+type MockService_RequestRecovery_Call struct {
+	*mock.Call
+}
+
+// RequestRecovery is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+func (_e *MockService_Expecter) RequestRecovery(ctx interface{}, email interface{}) *MockService_RequestRecovery_Call {
+	return &MockService_RequestRecovery_Call{Call: _e.mock.On("RequestRecovery", ctx, email)}
+}
+
+func (_c *MockService_RequestRecovery_Call) Run(run func(ctx context.Context, email string)) *MockService_RequestRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RequestRecovery_Call) Return(_a0 error) *MockService_RequestRecovery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// RotateAdminToken provides a mock function with given fields: ctx, adminToken
+func (_m *MockService) RotateAdminToken(ctx context.Context, adminToken string) (string, error) {
+	ret := _m.Called(ctx, adminToken)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, adminToken)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RotateAdminToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAdminToken'
+type MockService_RotateAdminToken_Call struct {
+	*mock.Call
+}
+
+// RotateAdminToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+func (_e *MockService_Expecter) RotateAdminToken(ctx interface{}, adminToken interface{}) *MockService_RotateAdminToken_Call {
+	return &MockService_RotateAdminToken_Call{Call: _e.mock.On("RotateAdminToken", ctx, adminToken)}
+}
+
+func (_c *MockService_RotateAdminToken_Call) Run(run func(ctx context.Context, adminToken string)) *MockService_RotateAdminToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RotateAdminToken_Call) Return(_a0 string, _a1 error) *MockService_RotateAdminToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// SearchGames provides a mock function with given fields: ctx, search, page
+func (_m *MockService) SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error) {
+	ret := _m.Called(ctx, search, page)
+
+	var r0 *wording.ListedGames
+	if rf, ok := ret.Get(0).(func(context.Context, wording.GameSearch, int) *wording.ListedGames); ok {
+		r0 = rf(ctx, search, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.ListedGames)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, wording.GameSearch, int) error); ok {
+		r1 = rf(ctx, search, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SearchGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchGames'
+type MockService_SearchGames_Call struct {
+	*mock.Call
+}
+
+// SearchGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - search wording.GameSearch
+//  - page int
+func (_e *MockService_Expecter) SearchGames(ctx interface{}, search interface{}, page interface{}) *MockService_SearchGames_Call {
+	return &MockService_SearchGames_Call{Call: _e.mock.On("SearchGames", ctx, search, page)}
+}
+
+func (_c *MockService_SearchGames_Call) Run(run func(ctx context.Context, search wording.GameSearch, page int)) *MockService_SearchGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(wording.GameSearch), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_SearchGames_Call) Return(_a0 *wording.ListedGames, _a1 error) *MockService_SearchGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Session provides a mock function with given fields: ctx, sessionToken
+func (_m *MockService) Session(ctx context.Context, sessionToken string) (*wording.Account, error) {
+	ret := _m.Called(ctx, sessionToken)
+
+	var r0 *wording.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Account); ok {
+		r0 = rf(ctx, sessionToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sessionToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Session_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Session'
+type MockService_Session_Call struct {
+	*mock.Call
+}
+
+// Session is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sessionToken string
+func (_e *MockService_Expecter) Session(ctx interface{}, sessionToken interface{}) *MockService_Session_Call {
+	return &MockService_Session_Call{Call: _e.mock.On("Session", ctx, sessionToken)}
+}
+
+func (_c *MockService_Session_Call) Run(run func(ctx context.Context, sessionToken string)) *MockService_Session_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Session_Call) Return(_a0 *wording.Account, _a1 error) *MockService_Session_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// SetGameDisabled provides a mock function with given fields: ctx, operator, adminToken, disabled
+func (_m *MockService) SetGameDisabled(ctx context.Context, operator string, adminToken string, disabled bool) error {
+	ret := _m.Called(ctx, operator, adminToken, disabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, operator, adminToken, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetGameDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGameDisabled'
+type MockService_SetGameDisabled_Call struct {
+	*mock.Call
+}
+
+// SetGameDisabled is a helper method to define mock.On call
+//  - ctx context.Context
+//  - operator string
+//  - adminToken string
+//  - disabled bool
+func (_e *MockService_Expecter) SetGameDisabled(ctx interface{}, operator interface{}, adminToken interface{}, disabled interface{}) *MockService_SetGameDisabled_Call {
+	return &MockService_SetGameDisabled_Call{Call: _e.mock.On("SetGameDisabled", ctx, operator, adminToken, disabled)}
+}
+
+func (_c *MockService_SetGameDisabled_Call) Run(run func(ctx context.Context, operator string, adminToken string, disabled bool)) *MockService_SetGameDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SetGameDisabled_Call) Return(_a0 error) *MockService_SetGameDisabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SetRecoveryEmail provides a mock function with given fields: ctx, adminToken, email
+func (_m *MockService) SetRecoveryEmail(ctx context.Context, adminToken string, email string) error {
+	ret := _m.Called(ctx, adminToken, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, adminToken, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetRecoveryEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRecoveryEmail'
+type MockService_SetRecoveryEmail_Call struct {
+	*mock.Call
+}
+
+// SetRecoveryEmail is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - email string
+func (_e *MockService_Expecter) SetRecoveryEmail(ctx interface{}, adminToken interface{}, email interface{}) *MockService_SetRecoveryEmail_Call {
+	return &MockService_SetRecoveryEmail_Call{Call: _e.mock.On("SetRecoveryEmail", ctx, adminToken, email)}
+}
+
+func (_c *MockService_SetRecoveryEmail_Call) Run(run func(ctx context.Context, adminToken string, email string)) *MockService_SetRecoveryEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_SetRecoveryEmail_Call) Return(_a0 error) *MockService_SetRecoveryEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// Stats provides a mock function with given fields: ctx
+func (_m *MockService) Stats(ctx context.Context) (wording.Stats, error) {
+	ret := _m.Called(ctx)
+
+	var r0 wording.Stats
+	if rf, ok := ret.Get(0).(func(context.Context) wording.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(wording.Stats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
@@ -416,6 +1436,45 @@ func (_c *MockService_SubmitGuess_Call) Return(_a0 error) *MockService_SubmitGue
 	return _c
 }
 
+// UpdateGameSettings provides a mock function with given fields: ctx, adminToken, settings
+func (_m *MockService) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	ret := _m.Called(ctx, adminToken, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, wording.GameSettings) error); ok {
+		r0 = rf(ctx, adminToken, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_UpdateGameSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGameSettings'
+type MockService_UpdateGameSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateGameSettings is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - settings wording.GameSettings
+func (_e *MockService_Expecter) UpdateGameSettings(ctx interface{}, adminToken interface{}, settings interface{}) *MockService_UpdateGameSettings_Call {
+	return &MockService_UpdateGameSettings_Call{Call: _e.mock.On("UpdateGameSettings", ctx, adminToken, settings)}
+}
+
+func (_c *MockService_UpdateGameSettings_Call) Run(run func(ctx context.Context, adminToken string, settings wording.GameSettings)) *MockService_UpdateGameSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(wording.GameSettings))
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateGameSettings_Call) Return(_a0 error) *MockService_UpdateGameSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 type mockConstructorTestingTNewMockService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/signer"
+)
+
+const (
+	playerTokenCookie  = "WordingToken"
+	playerCookieMaxAge = 365 * 24 * time.Hour
+)
+
+type playerTokenKey struct{}
+
+// PlayerIdentity is middleware that guarantees every request carries a
+// single, stable player token. The token is issued (or re-issued) as a
+// cookie on the response if needed and made available to handlers through
+// the request context. A browser that is logged in plays as its account
+// instead, so the same token follows the player to every device.
+func (s *Server) PlayerIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := s.identifyPlayer(ctx, w, r)
+		if account := s.loggedIn(ctx, w, r); account != nil {
+			token = account.PlayerToken
+			ctx = withAccount(ctx, account)
+		}
+
+		next.ServeHTTP(w, r.WithContext(withPlayerToken(ctx, token)))
+	})
+}
+
+func withPlayerToken(ctx context.Context, token string) context.Context {
+	ctx = context.WithValue(ctx, playerTokenKey{}, token)
+	return logging.AddFields(ctx, logrus.Fields{"player": logging.HashToken(token)})
+}
+
+// playerToken returns the player token that PlayerIdentity attached to the
+// request context.
+func playerToken(ctx context.Context) string {
+	token, _ := ctx.Value(playerTokenKey{}).(string)
+	return token
+}
+
+// identifyPlayer identifies the player making the request. A signed cookie
+// is trusted as-is. An unsigned cookie from before cookies were signed is
+// re-issued with a signature so existing players keep their progress, and
+// anything else (missing or tampered) is replaced with a brand new token.
+func (s *Server) identifyPlayer(ctx context.Context, w http.ResponseWriter, r *http.Request) string {
+	c, err := r.Cookie(playerTokenCookie)
+	if err != nil {
+		return s.issuePlayerToken(w, s.svc.NewPlayerToken(ctx))
+	}
+
+	token, err := s.signer.Verify(c.Value)
+	if err == nil {
+		return token
+	}
+
+	if errors.Is(err, signer.ErrUnsigned) && isLegacyPlayerToken(c.Value) {
+		return s.issuePlayerToken(w, c.Value)
+	}
+
+	return s.issuePlayerToken(w, s.svc.NewPlayerToken(ctx))
+}
+
+func (s *Server) issuePlayerToken(w http.ResponseWriter, token string) string {
+	http.SetCookie(w, &http.Cookie{
+		Name:     playerTokenCookie,
+		Value:    s.signer.Sign(token),
+		Path:     "/",
+		MaxAge:   int(playerCookieMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.baseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return token
+}
+
+// isLegacyPlayerToken reports whether the unsigned cookie value looks like
+// one of the bare UUIDs that were handed out before cookies were signed.
+func isLegacyPlayerToken(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestPlayerTokenCookie(t *testing.T) {
+	const legacyToken = "0b9c8ad2-43f8-4d52-b5e4-bd3c3c5e1f0e"
+
+	tests := []struct {
+		name      string
+		cookie    func(s *Server) string
+		wantToken string
+		wantSet   bool
+	}{
+		{
+			name:      "no cookie",
+			wantToken: "fresh-player",
+			wantSet:   true,
+		},
+		{
+			name:      "signed cookie",
+			cookie:    func(s *Server) string { return s.signer.Sign("returning-player") },
+			wantToken: "returning-player",
+		},
+		{
+			name:      "legacy unsigned cookie",
+			cookie:    func(*Server) string { return legacyToken },
+			wantToken: legacyToken,
+			wantSet:   true,
+		},
+		{
+			name: "tampered cookie",
+			cookie: func(s *Server) string {
+				return "someone-else" + s.signer.Sign("returning-player")[len("returning-player"):]
+			},
+			wantToken: "fresh-player",
+			wantSet:   true,
+		},
+		{
+			name:      "unsigned cookie that is not legacy",
+			cookie:    func(*Server) string { return "someone-else" },
+			wantToken: "fresh-player",
+			wantSet:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewMockService(t)
+			svr := New("https://wording.example", svc, newTestSigner(t), newTestLogger())
+
+			svc.EXPECT().NewPlayerToken(mock.Anything).Return("fresh-player").Maybe()
+			svc.EXPECT().
+				GameByToken(mock.Anything, "hungry-hippo").
+				Return(&wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6}, nil)
+			svc.EXPECT().
+				GameState(mock.Anything, "hungry-hippo", tt.wantToken).
+				Return(&wording.GameState{CanContinue: true}, nil).
+				Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/game/hungry-hippo", nil)
+			if tt.cookie != nil {
+				r.AddCookie(&http.Cookie{Name: playerTokenCookie, Value: tt.cookie(svr)})
+			}
+
+			router := chi.NewRouter()
+			router.Use(svr.PlayerIdentity)
+			router.Get("/game/{token}", svr.PlayGame)
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+			cookies := w.Result().Cookies()
+			if !tt.wantSet {
+				assert.Equal(t, 0, len(cookies))
+				return
+			}
+
+			assert.Equal(t, 1, len(cookies))
+			c := cookies[0]
+			got, err := svr.signer.Verify(c.Value)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantToken, got)
+			assert.Assert(t, c.HttpOnly)
+			assert.Assert(t, c.Secure)
+			assert.Equal(t, http.SameSiteLaxMode, c.SameSite)
+			assert.Assert(t, c.MaxAge > 0)
+		})
+	}
+}
+
+func TestPlayerIdentityIsStableAcrossFirstVisit(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Use(svr.PlayerIdentity)
+	router.Get("/game/{token}", svr.PlayGame)
+	router.Post("/game/{token}", svr.Guess)
+
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("first-visit").Once()
+	svc.EXPECT().
+		GameByToken(mock.Anything, "hungry-hippo").
+		Return(&wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6}, nil)
+	svc.EXPECT().
+		GameState(mock.Anything, "hungry-hippo", "first-visit").
+		Return(&wording.GameState{CanContinue: true}, nil).
+		Once()
+	svc.EXPECT().
+		SubmitGuess(mock.Anything, "hungry-hippo", "first-visit", "tomato").
+		Return(nil).
+		Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/game/hungry-hippo", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+	cookies := w.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+
+	form := url.Values{"guess": {"tomato"}}
+	r := httptest.NewRequest("POST", "/game/hungry-hippo", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(cookies[0])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
+	assert.Equal(t, 0, len(w.Result().Cookies()))
+}
+
+func TestPlayerIdentityLoggedIn(t *testing.T) {
+	svc := NewMockService(t)
+	signer := newTestSigner(t)
+	svr := New("http://localhost:8080", svc, signer, newTestLogger())
+
+	var got string
+	handler := svr.PlayerIdentity(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = playerToken(r.Context())
+	}))
+
+	svc.EXPECT().
+		Session(mock.Anything, "good-session").
+		Return(&wording.Account{ID: 1, PlayerToken: "account-player"}, nil).
+		Once()
+	svc.EXPECT().
+		Session(mock.Anything, "old-session").
+		Return(nil, service.ErrNotFound).
+		Once()
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: playerTokenCookie, Value: signer.Sign("this-browser")})
+	r.AddCookie(&http.Cookie{Name: sessionCookie, Value: signer.Sign("good-session")})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, "account-player", got)
+	assert.Equal(t, 0, len(w.Result().Cookies()))
+
+	// A session that is over goes back to the browser's own player.
+	r = httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: playerTokenCookie, Value: signer.Sign("this-browser")})
+	r.AddCookie(&http.Cookie{Name: sessionCookie, Value: signer.Sign("old-session")})
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, "this-browser", got)
+
+	cookies := w.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+	assert.Equal(t, sessionCookie, cookies[0].Name)
+	assert.Assert(t, cookies[0].MaxAge < 0)
+}
+
+func TestLogoutForgetsAccount(t *testing.T) {
+	svc := NewMockService(t)
+	signer := newTestSigner(t)
+	svr := New("http://localhost:8080", svc, signer, newTestLogger())
+
+	router := chi.NewRouter()
+	router.Use(svr.PlayerIdentity)
+	router.Post("/logout", svr.Logout)
+	router.Get("/my-games", svr.MyGames)
+
+	svc.EXPECT().
+		Session(mock.Anything, "good-session").
+		Return(&wording.Account{ID: 1, PlayerToken: "account-player"}, nil).
+		Once()
+	svc.EXPECT().Logout(mock.Anything, "good-session").Return(nil).Once()
+	svc.EXPECT().NewPlayerToken(mock.Anything).Return("fresh-player").Once()
+
+	// Even a browser whose own token is the account's, as the first login
+	// used to leave it, mustn't keep playing as the account.
+	r := httptest.NewRequest("POST", "/logout", nil)
+	r.AddCookie(&http.Cookie{Name: playerTokenCookie, Value: signer.Sign("account-player")})
+	r.AddCookie(&http.Cookie{Name: sessionCookie, Value: signer.Sign("good-session")})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
+
+	svc.EXPECT().
+		CreatedGames(mock.Anything, "fresh-player", 1).
+		Return(&wording.CreatedGames{Page: 1}, nil).
+		Once()
+	svc.EXPECT().MailEnabled().Return(true)
+
+	r = httptest.NewRequest("GET", "/my-games", nil)
+	for _, c := range w.Result().Cookies() {
+		if c.MaxAge > 0 {
+			r.AddCookie(c)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+	assert.Assert(t, !strings.Contains(w.Body.String(), "/manage/"), w.Body)
+}
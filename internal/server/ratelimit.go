@@ -0,0 +1,65 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/ratelimit"
+)
+
+// KeyFunc picks what a rate limit is counted against.
+type KeyFunc func(r *http.Request) string
+
+// ByIP counts requests against the client's IP address. It relies on chi's
+// RealIP middleware to have already resolved the address when the server is
+// behind a proxy.
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ByPlayer counts requests against the player token. It must run after
+// PlayerIdentity.
+func ByPlayer(r *http.Request) string {
+	return playerToken(r.Context())
+}
+
+// ByGame counts requests against the game being played.
+func ByGame(r *http.Request) string {
+	return chi.URLParam(r, "token")
+}
+
+// RateLimit is middleware that rejects requests with 429 Too Many Requests
+// once the limiter's budget for the request's key is used up.
+func (s *Server) RateLimit(limiter ratelimit.Limiter, key KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decision, err := limiter.Allow(r.Context(), key(r))
+			if err != nil {
+				// Fail open: a broken limiter shouldn't take the site down.
+				logging.From(r.Context(), s.log).WithError(err).Warn("checking rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !decision.Allowed {
+				logging.From(r.Context(), s.log).Debug("rate limited")
+
+				retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
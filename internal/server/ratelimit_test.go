@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	svr := New("http://localhost:8080", NewMockService(t), newTestSigner(t), newTestLogger())
+	limiter := ratelimit.NewMemory(ratelimit.Rule{Requests: 1, Per: time.Minute})
+
+	router := chi.NewRouter()
+	router.With(svr.RateLimit(limiter, ByGame)).Post("/game/{token}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	do := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusNoContent, do("/game/hungry-hippo").Code)
+
+	w := do("/game/hungry-hippo")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Result().Header.Get("Retry-After"))
+
+	assert.Equal(t, http.StatusNoContent, do("/game/wretched-apostle").Code)
+}
+
+func TestByIP(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+
+	r.RemoteAddr = "203.0.113.7:51234"
+	assert.Equal(t, "203.0.113.7", ByIP(r))
+
+	// chi's RealIP middleware replaces RemoteAddr with a bare address.
+	r.RemoteAddr = "203.0.113.7"
+	assert.Equal(t, "203.0.113.7", ByIP(r))
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/view"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// RotateAdminLink replaces a game's admin link, for when it has leaked.
+func (s *Server) RotateAdminLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminToken, err := s.svc.RotateAdminToken(ctx, chi.URLParam(r, "admin_token"))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/manage/%s?rotated=1", adminToken), http.StatusSeeOther)
+}
+
+// SetRecoveryEmail sets or removes the address a game's new admin links
+// can be sent to.
+func (s *Server) SetRecoveryEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminToken := chi.URLParam(r, "admin_token")
+
+	_ = r.ParseForm()
+
+	err := s.svc.SetRecoveryEmail(ctx, adminToken, r.PostFormValue("email"))
+	var violations wording.InputViolations
+	if errors.As(err, &violations) {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(": %v", err), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/manage/%s", adminToken), http.StatusSeeOther)
+}
+
+// RecoverPage asks for a recovery email to send a recovery link to.
+func (s *Server) RecoverPage(w http.ResponseWriter, r *http.Request) {
+	if !s.svc.MailEnabled() {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	err := view.Recover{
+		CSRFToken: csrfToken(r.Context()),
+		Sent:      r.URL.Query().Get("sent") != "",
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// RequestRecovery emails a recovery link to the recovery email in the
+// form, if it has any games.
+func (s *Server) RequestRecovery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_ = r.ParseForm()
+
+	err := s.svc.RequestRecovery(ctx, r.PostFormValue("email"))
+	var violations wording.InputViolations
+	if errors.As(err, &violations) {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(": %v", err), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrMailDisabled) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Error("sending recovery link")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/recover?sent=1", http.StatusSeeOther)
+}
+
+// ConfirmRecovery is where recovery links lead. Replacing the admin links
+// takes another click, so that mail scanners which follow links don't use
+// them up.
+func (s *Server) ConfirmRecovery(w http.ResponseWriter, r *http.Request) {
+	err := view.Recover{
+		CSRFToken: csrfToken(r.Context()),
+		Token:     chi.URLParam(r, "token"),
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// RecoverGames uses up a recovery link and shows the new admin links for
+// its games. They're only ever shown here.
+func (s *Server) RecoverGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	games, err := s.svc.RecoverGames(ctx, chi.URLParam(r, "token"))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound)+": this recovery link has expired or has already been used", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Error("recovering games")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	recovered := make([]view.RecoveredGame, len(games))
+	for i, g := range games {
+		recovered[i] = view.RecoveredGame{AdminToken: g.AdminToken, Token: g.Token}
+	}
+
+	// The page holds the only copy of the new admin links.
+	w.Header().Set("Cache-Control", "no-store")
+
+	err = view.Recover{
+		BaseURL:   s.baseURL,
+		Recovered: true,
+		Games:     recovered,
+	}.RenderTo(w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
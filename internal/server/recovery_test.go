@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestRecoverGames(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Get("/recover/{token}", svr.ConfirmRecovery)
+	router.Post("/recover/{token}", svr.RecoverGames)
+
+	// Following the link changes nothing by itself.
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/recover/s3cret", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+	assert.Assert(t, strings.Contains(w.Body.String(), `action="/recover/s3cret"`), w.Body)
+
+	svc.EXPECT().
+		RecoverGames(mock.Anything, "s3cret").
+		Return([]wording.Game{{AdminToken: "fresh", Token: "hungry-hippo"}}, nil).
+		Once()
+	svc.EXPECT().
+		RecoverGames(mock.Anything, "s3cret").
+		Return(nil, service.ErrNotFound).
+		Once()
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/recover/s3cret", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Assert(t, strings.Contains(w.Body.String(), "http://localhost:8080/manage/fresh"), w.Body)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/recover/s3cret", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body)
+}
@@ -4,23 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
 
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/export"
+	"github.com/connorkuehl/wording/internal/logging"
 	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/signer"
 	"github.com/connorkuehl/wording/internal/view"
 	"github.com/connorkuehl/wording/internal/wording"
 )
 
-const playerTokenCookie = "WordingToken"
-
 //go:generate mockery --name Service --case underscore --with-expecter --testonly --inpackage
 type Service interface {
-	CreateGame(ctx context.Context, answer string, guessLimit int) (*wording.Game, error)
+	CreateGame(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string) (*wording.Game, error)
 	Game(ctx context.Context, adminToken string) (*wording.Game, error)
 	GameByToken(ctx context.Context, token string) (*wording.Game, error)
 	SubmitGuess(ctx context.Context, gameToken, playerToken, guess string) error
@@ -28,34 +31,65 @@ type Service interface {
 	NewPlayerToken(ctx context.Context) string
 	Stats(ctx context.Context) (wording.Stats, error)
 	DeleteGame(ctx context.Context, adminToken string) error
-	GameStats(ctx context.Context, adminToken string) (wording.Stats, error)
+	GameStats(ctx context.Context, adminToken string) (wording.GameStats, error)
+	ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error)
+	DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error)
+	GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error)
+	PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error)
+	PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error)
+	ClearPlayerHistory(ctx context.Context, playerToken string) (int, error)
+	CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error)
+	DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error)
+	RotateAdminToken(ctx context.Context, adminToken string) (string, error)
+	SetRecoveryEmail(ctx context.Context, adminToken, email string) error
+	RequestRecovery(ctx context.Context, email string) error
+	RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error)
+	SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error)
+	InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error)
+	SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error
+	OperatorDeleteGame(ctx context.Context, operator, adminToken string) error
+	AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error)
+	MailEnabled() bool
+	RequestLogin(ctx context.Context, email string) error
+	Login(ctx context.Context, loginToken, playerToken string) (*wording.Session, error)
+	Session(ctx context.Context, sessionToken string) (*wording.Account, error)
+	Logout(ctx context.Context, sessionToken string) error
+	Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error)
+	UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error
 }
 
 // Server is the HTTP "edge" of the web application.
 type Server struct {
 	baseURL string
 	svc     Service
+	signer  *signer.Signer
+	log     logrus.FieldLogger
 }
 
-// New creates a new Server.
-func New(baseURL string, svc Service) *Server {
+// New creates a new Server. The signer is used to protect the player
+// identity cookie from tampering.
+func New(baseURL string, svc Service, signer *signer.Signer, logger logrus.FieldLogger) *Server {
 	return &Server{
 		baseURL: baseURL,
 		svc:     svc,
+		signer:  signer,
+		log:     logger,
 	}
 }
 
 // Home renders the root of the site, which is the game creation page.
 func (s *Server) Home(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	stats, err := s.svc.Stats(ctx)
 	if err != nil {
-		// TODO
-		log.Println("read stats:", err)
+		logging.From(ctx, s.log).WithError(err).Warn("reading stats")
 	}
 
-	err = view.Home{Stats: stats}.RenderTo(w)
+	err = view.Home{
+		CSRFToken: csrfToken(r.Context()),
+		Stats:     stats,
+	}.RenderTo(w)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
@@ -64,7 +98,7 @@ func (s *Server) Home(w http.ResponseWriter, r *http.Request) {
 
 // CreateGame handles incoming POST forms for creating a new game.
 func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	var (
 		answer      string
@@ -74,10 +108,6 @@ func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
 	_ = r.ParseForm()
 
 	answer = r.PostFormValue("answer")
-	if answer == "" {
-		http.Error(w, http.StatusText(http.StatusBadRequest)+" answer is missing", http.StatusBadRequest)
-		return
-	}
 
 	i, err := strconv.Atoi(r.PostFormValue("num_attempts"))
 	if err != nil {
@@ -86,7 +116,13 @@ func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
 	}
 	numAttempts = i
 
-	game, err := s.svc.CreateGame(ctx, answer, numAttempts)
+	settings, err := formSettings(r)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(" %v", err), http.StatusBadRequest)
+		return
+	}
+
+	game, err := s.svc.CreateGame(ctx, answer, numAttempts, settings, playerToken(ctx))
 
 	var invalidInput wording.InputViolations
 	if errors.As(err, &invalidInput) {
@@ -104,7 +140,7 @@ func (s *Server) CreateGame(w http.ResponseWriter, r *http.Request) {
 // ManageGame renders the manage game page, where a game's stats are shown and it
 // can optionally be deleted.
 func (s *Server) ManageGame(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	adminToken := chi.URLParam(r, "admin_token")
 	if adminToken == "" {
@@ -124,18 +160,41 @@ func (s *Server) ManageGame(w http.ResponseWriter, r *http.Request) {
 
 	stats, err := s.svc.GameStats(ctx, adminToken)
 	if err != nil {
-		// TODO
-		log.Println(err)
+		logging.From(ctx, s.log).WithError(err).Warn("reading game stats")
+	}
+
+	days, err := s.svc.GameDailyStats(ctx, adminToken, manageStatsDays)
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Warn("reading daily game stats")
 	}
 
+	now := time.Now()
 	err = view.ManageGame{
-		BaseURL:        s.baseURL,
-		AdminToken:     game.AdminToken,
-		Token:          game.Token,
-		Answer:         game.Answer,
-		GuessesAllowed: game.GuessLimit,
-		GuessesMade:    stats.GuessesMade,
-		CorrectGuesses: stats.GamesWon,
+		CSRFToken:         csrfToken(r.Context()),
+		BaseURL:           s.baseURL,
+		AdminToken:        game.AdminToken,
+		Token:             game.Token,
+		Answer:            game.Answer,
+		GuessesAllowed:    game.GuessLimit,
+		GuessesMade:       stats.GuessesMade,
+		CorrectGuesses:    stats.GamesWon,
+		PlayersStarted:    stats.PlayersStarted,
+		PlayersFinished:   stats.PlayersFinished,
+		Losses:            stats.Losses,
+		Distribution:      distributionView(stats),
+		FirstGuesses:      wordCountsView(stats.FirstGuesses),
+		WrongFinalGuesses: wordCountsView(stats.WrongFinalGuesses),
+		Difficulty:        difficultyView(game),
+		Days:              daysView(days),
+		Rotated:           r.URL.Query().Get("rotated") != "",
+		Recoverable:       game.Recoverable,
+		MailEnabled:       s.svc.MailEnabled(),
+		Disabled:          game.Disabled,
+		OpensAt:           formTime(game.OpensAt),
+		ClosesAt:          formTime(game.ClosesAt),
+		Closed:            game.Closed(now),
+		Reveal:            string(game.Reveal),
+		AnswerRevealed:    game.RevealsAnswer(false, false, now),
 	}.RenderTo(w)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -145,7 +204,7 @@ func (s *Server) ManageGame(w http.ResponseWriter, r *http.Request) {
 
 // PlayGame renders the play game page.
 func (s *Server) PlayGame(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	token := chi.URLParam(r, "token")
 	if token == "" {
@@ -158,25 +217,62 @@ func (s *Server) PlayGame(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
+	if errors.Is(err, service.ErrGameDisabled) {
+		http.Error(w, http.StatusText(http.StatusGone)+": this game has been disabled", http.StatusGone)
+		return
+	}
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	var id string
-	idCookie, err := r.Cookie(playerTokenCookie)
-	if err != nil {
-		id = s.svc.NewPlayerToken(ctx)
-	} else {
-		id = idCookie.Value
+	now := time.Now()
+	if !game.Opened(now) {
+		err = view.PlayGame{
+			Token:   token,
+			Length:  len(game.Answer),
+			OpensAt: game.OpensAt.UTC().Format(scheduleTimeLayout),
+			OpensIn: countdown(game.OpensAt.Sub(now)),
+			Opens:   game.OpensAt.Unix(),
+		}.RenderTo(w)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
 	}
 
+	id := playerToken(ctx)
+
 	state, err := s.svc.GameState(ctx, game.Token, id)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	v := view.PlayGame{
+		CSRFToken: csrfToken(r.Context()),
+		Token:     token,
+		Length:    len(game.Answer),
+		GameState: state,
+		Answer:    strings.ToUpper(state.Answer),
+	}
+
+	if game.Closed(now) {
+		standings, err := s.svc.Leaderboard(ctx, game.Token)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		v.Closed = true
+		v.Leaderboard = make([]view.Standing, len(standings))
+		for i, st := range standings {
+			v.Leaderboard[i] = view.Standing{Rank: st.Rank, Guesses: st.Guesses, You: st.PlayerToken == id}
+		}
+	} else if !game.ClosesAt.IsZero() {
+		v.ClosesAt = game.ClosesAt.UTC().Format(scheduleTimeLayout)
+	}
+
 	for _, attempt := range state.Attempts {
 		for i := range attempt {
 			attempt[i].Value = strings.ToUpper(attempt[i].Value)
@@ -188,11 +284,7 @@ func (s *Server) PlayGame(w http.ResponseWriter, r *http.Request) {
 		state.Attempts = append(state.Attempts, wording.Attempt{})
 	}
 
-	err = view.PlayGame{
-		Token:     token,
-		Length:    len(game.Answer),
-		GameState: state,
-	}.RenderTo(w)
+	err = v.RenderTo(w)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
@@ -201,35 +293,33 @@ func (s *Server) PlayGame(w http.ResponseWriter, r *http.Request) {
 
 // Guess handles the POST form data for a player submitting a guess for a game.
 func (s *Server) Guess(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	token := chi.URLParam(r, "token")
 
-	var id string
-	idCookie, err := r.Cookie(playerTokenCookie)
-	if err != nil {
-		id = s.svc.NewPlayerToken(ctx)
-		http.SetCookie(w, &http.Cookie{Name: playerTokenCookie, Value: id})
-	} else {
-		id = idCookie.Value
-	}
+	id := playerToken(ctx)
 
 	_ = r.ParseForm()
 
 	guess := r.PostForm.Get("guess")
 
-	err = s.svc.SubmitGuess(ctx, token, id, guess)
+	err := s.svc.SubmitGuess(ctx, token, id, guess)
 	var violations wording.InputViolations
 	if errors.As(err, &violations) {
 		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(": %v", err), http.StatusBadRequest)
 		return
 	}
-	if errors.Is(err, service.ErrGuessLimitReached) || errors.Is(err, service.ErrCannotContinue) {
+	if errors.Is(err, service.ErrGuessLimitReached) || errors.Is(err, service.ErrCannotContinue) ||
+		errors.Is(err, service.ErrGameNotOpen) || errors.Is(err, service.ErrGameClosed) {
 		http.Redirect(w, r, fmt.Sprintf("/game/%s", token), http.StatusSeeOther)
 		return
 	}
+	if errors.Is(err, service.ErrGameDisabled) {
+		http.Error(w, http.StatusText(http.StatusGone)+": this game has been disabled", http.StatusGone)
+		return
+	}
 	if err != nil {
-		log.Println(err)
+		logging.From(ctx, s.log).WithError(err).Error("submitting guess")
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
@@ -237,8 +327,38 @@ func (s *Server) Guess(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, fmt.Sprintf("/game/%s", token), http.StatusSeeOther)
 }
 
+// UpdateGameSettings handles the POST form for changing a game's settings.
+func (s *Server) UpdateGameSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminToken := chi.URLParam(r, "admin_token")
+
+	settings, err := formSettings(r)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(" %v", err), http.StatusBadRequest)
+		return
+	}
+
+	err = s.svc.UpdateGameSettings(ctx, adminToken, settings)
+	var violations wording.InputViolations
+	if errors.As(err, &violations) {
+		http.Error(w, http.StatusText(http.StatusBadRequest)+fmt.Sprintf(": %v", err), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/manage/%s", adminToken), http.StatusSeeOther)
+}
+
 func (s *Server) DeleteGame(w http.ResponseWriter, r *http.Request) {
-	ctx := context.TODO()
+	ctx := r.Context()
 
 	token := chi.URLParam(r, "admin_token")
 
@@ -254,3 +374,150 @@ func (s *Server) DeleteGame(w http.ResponseWriter, r *http.Request) {
 
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
+
+// ExportGame downloads a game and everything played against it in the
+// format "wording import" reads.
+func (s *Server) ExportGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	record, err := s.svc.ExportGame(ctx, chi.URLParam(r, "admin_token"))
+	if errors.Is(err, service.ErrNotFound) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="wording-%s.jsonl"`, record.Token))
+
+	err = export.NewWriter(w).WriteGame(record)
+	if err != nil {
+		logging.From(ctx, s.log).WithError(err).Warn("writing export")
+	}
+}
+
+// formTimeLayout is how datetime-local inputs send times. The forms ask
+// for them in UTC.
+const formTimeLayout = "2006-01-02T15:04"
+
+// scheduleTimeLayout is how a game's schedule is shown to players.
+const scheduleTimeLayout = "2006-01-02 15:04 UTC"
+
+// formSettings reads a game's settings from a create or edit form. Empty
+// times leave that end of the schedule open, and the service checks the
+// reveal setting.
+func formSettings(r *http.Request) (wording.GameSettings, error) {
+	settings := wording.GameSettings{Reveal: wording.Reveal(r.PostFormValue("reveal"))}
+
+	fields := []struct {
+		name string
+		t    *time.Time
+	}{
+		{"opens_at", &settings.OpensAt},
+		{"closes_at", &settings.ClosesAt},
+	}
+	for _, f := range fields {
+		v := r.PostFormValue(f.name)
+		if v == "" {
+			continue
+		}
+
+		t, err := time.ParseInLocation(formTimeLayout, v, time.UTC)
+		if err != nil {
+			return settings, fmt.Errorf("%s must be a date and time", f.name)
+		}
+		*f.t = t
+	}
+
+	return settings, nil
+}
+
+// formTime formats t for a datetime-local input, or leaves it empty if t
+// is zero.
+func formTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(formTimeLayout)
+}
+
+// countdown says roughly how long d is, e.g. "2d 3h 4m 5s".
+func countdown(d time.Duration) string {
+	secs := int(d.Round(time.Second) / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+
+	days, hours, mins := secs/86400, secs%86400/3600, secs%3600/60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm %ds", days, hours, mins, secs%60)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm %ds", hours, mins, secs%60)
+	case mins > 0:
+		return fmt.Sprintf("%dm %ds", mins, secs%60)
+	}
+	return fmt.Sprintf("%ds", secs)
+}
+
+// distributionView charts how many guesses winners took, then how many
+// players lost.
+func distributionView(stats wording.GameStats) []view.Bar {
+	labels := make([]string, 0, len(stats.WinsIn)+1)
+	counts := make([]int, 0, len(stats.WinsIn)+1)
+
+	for i, n := range stats.WinsIn {
+		labels = append(labels, strconv.Itoa(i+1))
+		counts = append(counts, n)
+	}
+
+	labels = append(labels, "Lost")
+	counts = append(counts, stats.Losses)
+
+	return view.Bars(labels, counts)
+}
+
+func wordCountsView(words []wording.WordCount) []view.Bar {
+	labels := make([]string, len(words))
+	counts := make([]int, len(words))
+	for i, w := range words {
+		labels[i], counts[i] = w.Word, w.Count
+	}
+
+	return view.Bars(labels, counts)
+}
+
+// manageStatsDays is how many days of stats the manage page shows.
+const manageStatsDays = 14
+
+func daysView(days []wording.DailyStats) []view.Day {
+	v := make([]view.Day, len(days))
+	for i, d := range days {
+		v[i] = view.Day{
+			Date:           d.Day.Format(api.DateLayout),
+			GamesCreated:   d.GamesCreated,
+			PlayersStarted: d.PlayersStarted,
+			GuessesMade:    d.GuessesMade,
+			GamesWon:       d.GamesWon,
+		}
+	}
+	return v
+}
+
+func difficultyView(game *wording.Game) *view.Difficulty {
+	d := game.Difficulty
+	if d == nil {
+		return nil
+	}
+
+	return &view.Difficulty{
+		Score:           d.Score,
+		Label:           d.Label(),
+		SolverGuesses:   d.SolverGuesses,
+		ExpectedGuesses: d.ExpectedGuesses(),
+		LimitTooLow:     d.LimitTooLow(game.GuessLimit),
+	}
+}
@@ -8,15 +8,32 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/mock"
 	"gotest.tools/assert"
 
+	"github.com/connorkuehl/wording/internal/export"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/signer"
 	"github.com/connorkuehl/wording/internal/wording"
 )
 
+func newTestSigner(t *testing.T) *signer.Signer {
+	s, err := signer.New([]byte("test-secret"))
+	assert.NilError(t, err)
+	return s
+}
+
+func newTestLogger() logrus.FieldLogger {
+	logger, _ := test.NewNullLogger()
+	return logger
+}
+
 func TestCreateGame(t *testing.T) {
 	svc := NewMockService(t)
-	svr := New("http://localhost:8080", svc)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
 
 	form := url.Values{
 		"answer":        {"potato"},
@@ -27,9 +44,10 @@ func TestCreateGame(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/games", strings.NewReader(form.Encode()))
 	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r = r.WithContext(withPlayerToken(r.Context(), "player-one"))
 
 	svc.EXPECT().
-		CreateGame(mock.Anything, "potato", 6).
+		CreateGame(mock.Anything, "potato", 6, wording.GameSettings{}, "player-one").
 		Return(&wording.Game{
 			AdminToken: "wretched-apostle",
 			Answer:     "potato",
@@ -42,3 +60,260 @@ func TestCreateGame(t *testing.T) {
 	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
 	assert.DeepEqual(t, []string{"/manage/wretched-apostle"}, w.Result().Header["Location"])
 }
+
+func TestExportGame(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Get("/manage/{admin_token}/export", svr.ExportGame)
+
+	svc.EXPECT().
+		ExportGame(mock.Anything, "wretched-apostle").
+		Return(&wording.GameRecord{
+			Game: wording.Game{
+				AdminToken: "wretched-apostle",
+				Token:      "hungry-hippo",
+				Answer:     "potato",
+				GuessLimit: 6,
+			},
+			Players: []wording.PlayerRecord{
+				{PlayerToken: "player", Plays: wording.Plays{Attempts: []string{"tomato"}}},
+			},
+		}, nil).
+		Once()
+	svc.EXPECT().
+		ExportGame(mock.Anything, "nope").
+		Return(nil, service.ErrNotFound).
+		Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/manage/wretched-apostle/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+	assert.Equal(t, `attachment; filename="wording-hungry-hippo.jsonl"`, w.Header().Get("Content-Disposition"))
+
+	rec, err := export.NewReader(w.Body).Next()
+	assert.NilError(t, err)
+	assert.Equal(t, "potato", rec.Game.Answer)
+	assert.DeepEqual(t, []string{"tomato"}, rec.Game.Players[0].Attempts)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/manage/nope/export", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body)
+}
+
+func TestManageGameCharts(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Get("/manage/{admin_token}", svr.ManageGame)
+
+	svc.EXPECT().
+		Game(mock.Anything, "wretched-apostle").
+		Return(&wording.Game{AdminToken: "wretched-apostle", Token: "hungry-hippo", Answer: "potato", GuessLimit: 3}, nil)
+	svc.EXPECT().
+		GameStats(mock.Anything, "wretched-apostle").
+		Return(wording.TallyGameStats("potato", 3, []wording.Plays{
+			{Attempts: []string{"tomato", "potato"}},
+			{Attempts: []string{"tomato", "banana", "carrot"}},
+			{Attempts: []string{"tomato", "banana", "orange"}},
+		}), nil)
+	svc.EXPECT().
+		GameDailyStats(mock.Anything, "wretched-apostle", manageStatsDays).
+		Return(nil, nil)
+	svc.EXPECT().MailEnabled().Return(false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/manage/wretched-apostle", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"3 started, 3 finished, 2 ran out of guesses",
+		`<span>2</span><b style="width: 50%">1</b>`,
+		`<span>Lost</span><b style="width: 100%">2</b>`,
+		`<span>tomato</span><b style="width: 100%">3</b>`,
+		`<span>carrot</span><b style="width: 100%">1</b>`,
+	} {
+		assert.Assert(t, strings.Contains(body, want), "missing %q", want)
+	}
+}
+
+func TestManageGameClosed(t *testing.T) {
+	for reveal, want := range map[wording.Reveal]string{
+		wording.RevealOnLoss:  "Players can see the answer and the leaderboard.",
+		wording.RevealOnClose: "Players can see the answer and the leaderboard.",
+		wording.RevealNever:   "Players can see the leaderboard, but only those who guessed the answer know it.",
+	} {
+		t.Run(string(reveal), func(t *testing.T) {
+			svc := NewMockService(t)
+			svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+			router := chi.NewRouter()
+			router.Get("/manage/{admin_token}", svr.ManageGame)
+
+			svc.EXPECT().
+				Game(mock.Anything, "wretched-apostle").
+				Return(&wording.Game{
+					AdminToken:   "wretched-apostle",
+					Token:        "hungry-hippo",
+					Answer:       "potato",
+					GuessLimit:   3,
+					GameSettings: wording.GameSettings{ClosesAt: time.Now().Add(-time.Hour), Reveal: reveal},
+				}, nil)
+			svc.EXPECT().GameStats(mock.Anything, "wretched-apostle").Return(wording.GameStats{}, nil)
+			svc.EXPECT().GameDailyStats(mock.Anything, "wretched-apostle", manageStatsDays).Return(nil, nil)
+			svc.EXPECT().MailEnabled().Return(false)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/manage/wretched-apostle", nil))
+			assert.Equal(t, http.StatusOK, w.Code, w.Body)
+			assert.Assert(t, strings.Contains(w.Body.String(), "This game has closed. "+want), w.Body)
+		})
+	}
+}
+
+func TestMyGames(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	svc.EXPECT().
+		CreatedGames(mock.Anything, "player-one", 2).
+		Return(&wording.CreatedGames{
+			Games: []wording.CreatedGame{{
+				Game:      wording.Game{AdminToken: "wretched-apostle", Token: "hungry-hippo", Answer: "potato"},
+				CreatedAt: time.Date(2022, 11, 5, 12, 0, 0, 0, time.UTC),
+				Players:   4,
+				Wins:      3,
+			}},
+			Page: 2,
+			More: true,
+		}, nil).
+		Once()
+	svc.EXPECT().MailEnabled().Return(false)
+
+	r := httptest.NewRequest("GET", "/my-games?page=2", nil)
+	r = r.WithContext(withPlayerToken(r.Context(), "player-one"))
+
+	w := httptest.NewRecorder()
+	svr.MyGames(w, r)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`value="wretched-apostle"`,
+		`http://localhost:8080/game/hungry-hippo`,
+		`<td>4</td>`,
+		`<td>75%</td>`,
+		`href="/my-games?page=1"`,
+		`href="/my-games?page=3"`,
+	} {
+		assert.Assert(t, strings.Contains(body, want), "missing %q", want)
+	}
+}
+
+func TestDeleteMyGames(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	svc.EXPECT().
+		DeleteCreatedGames(mock.Anything, "player-one", []string{"wretched-apostle", "quiet-otter"}).
+		Return(1, nil).
+		Once()
+
+	form := url.Values{"admin_token": {"wretched-apostle", "quiet-otter"}}
+	r := httptest.NewRequest("POST", "/my-games/delete", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r = r.WithContext(withPlayerToken(r.Context(), "player-one"))
+
+	w := httptest.NewRecorder()
+	svr.DeleteMyGames(w, r)
+	assert.Equal(t, http.StatusSeeOther, w.Code, w.Body)
+	assert.Equal(t, "/my-games?deleted=1", w.Result().Header.Get("Location"))
+}
+
+func TestPlayGameDisabled(t *testing.T) {
+	svc := NewMockService(t)
+	svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+	router := chi.NewRouter()
+	router.Get("/game/{token}", svr.PlayGame)
+
+	svc.EXPECT().
+		GameByToken(mock.Anything, "hungry-hippo").
+		Return(nil, service.ErrGameDisabled).
+		Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/game/hungry-hippo", nil))
+	assert.Equal(t, http.StatusGone, w.Code, w.Body)
+}
+
+func TestPlayGameSchedule(t *testing.T) {
+	t.Run("not open yet", func(t *testing.T) {
+		svc := NewMockService(t)
+		svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+		router := chi.NewRouter()
+		router.Get("/game/{token}", svr.PlayGame)
+
+		svc.EXPECT().
+			GameByToken(mock.Anything, "hungry-hippo").
+			Return(&wording.Game{
+				Token:        "hungry-hippo",
+				Answer:       "potato",
+				GuessLimit:   3,
+				GameSettings: wording.GameSettings{OpensAt: time.Now().Add(2 * time.Hour)},
+			}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/game/hungry-hippo", nil))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body)
+		assert.Assert(t, strings.Contains(w.Body.String(), "hasn't opened yet"), w.Body)
+		assert.Assert(t, !strings.Contains(w.Body.String(), "POTATO"), w.Body)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		svc := NewMockService(t)
+		svr := New("http://localhost:8080", svc, newTestSigner(t), newTestLogger())
+
+		router := chi.NewRouter()
+		router.Get("/game/{token}", svr.PlayGame)
+
+		svc.EXPECT().
+			GameByToken(mock.Anything, "hungry-hippo").
+			Return(&wording.Game{
+				Token:        "hungry-hippo",
+				Answer:       "potato",
+				GuessLimit:   3,
+				GameSettings: wording.GameSettings{ClosesAt: time.Now().Add(-time.Hour)},
+			}, nil)
+		svc.EXPECT().
+			GameState(mock.Anything, "hungry-hippo", "player-one").
+			Return(&wording.GameState{Answer: "potato"}, nil)
+		svc.EXPECT().
+			Leaderboard(mock.Anything, "hungry-hippo").
+			Return([]wording.Standing{
+				{Rank: 1, PlayerToken: "player-two", Guesses: 2},
+				{Rank: 2, PlayerToken: "player-one", Guesses: 3},
+			}, nil)
+
+		r := httptest.NewRequest("GET", "/game/hungry-hippo", nil)
+		r = r.WithContext(withPlayerToken(r.Context(), "player-one"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body)
+
+		body := w.Body.String()
+		assert.Assert(t, strings.Contains(body, "This game has closed"), body)
+		assert.Assert(t, strings.Contains(body, "<strong>POTATO</strong>"), body)
+		assert.Assert(t, strings.Contains(body, "<strong>You</strong>"), body)
+		assert.Assert(t, !strings.Contains(body, "player-two"), body)
+		assert.Assert(t, !strings.Contains(body, `name="guess"`), body)
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+const (
+	// LoginLinkLifetime is how long a login link can be used for.
+	LoginLinkLifetime = 15 * time.Minute
+	// SessionLifetime is how long a player stays logged in.
+	SessionLifetime = 30 * 24 * time.Hour
+)
+
+// MailEnabled reports whether links can be mailed to players.
+func (s *service) MailEnabled() bool {
+	return s.mailer != nil
+}
+
+// RequestLogin emails a login link to the address. Whether or not the
+// address has an account already doesn't change anything, so the response
+// can't be used to find out who has one.
+func (s *service) RequestLogin(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return ErrMailDisabled
+	}
+
+	email = strings.TrimSpace(email)
+	err := wording.ValidateEmail(email)
+	if err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+
+	token, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	err = s.store.CreateLoginToken(ctx, hashSecret(token), hashEmail(email), time.Now().Add(LoginLinkLifetime))
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.SendLoginLink(ctx, email, token, LoginLinkLifetime)
+}
+
+// Login uses up a login token and starts a session. The first login for
+// an address creates its account with a player token of its own. Either
+// way, playerToken's plays and created games are merged into the account,
+// so nothing played before logging in is lost, and the browser keeps
+// nothing of the account's once it logs out. It returns ErrNotFound if the
+// token has expired or was already used.
+func (s *service) Login(ctx context.Context, loginToken, playerToken string) (*wording.Session, error) {
+	sessionToken, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(SessionLifetime)
+
+	account, err := s.store.StartSession(ctx, hashSecret(loginToken), playerToken, s.NewPlayerToken(ctx), hashSecret(sessionToken), expiresAt)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wording.Session{Token: sessionToken, Account: *account, ExpiresAt: expiresAt}, nil
+}
+
+// Session fetches the account a session is logged in to.
+func (s *service) Session(ctx context.Context, sessionToken string) (*wording.Account, error) {
+	account, err := s.store.AccountSession(ctx, hashSecret(sessionToken))
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return account, err
+}
+
+// Logout ends a session.
+func (s *service) Logout(ctx context.Context, sessionToken string) error {
+	return s.store.DeleteSession(ctx, hashSecret(sessionToken))
+}
+
+// newSecret returns a random token that is hard enough to guess to be
+// used as a credential.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret hashes a credential so that the database never holds one
+// that could be used as-is.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashEmail hashes an email address so that accounts can be looked up by
+// address without storing it.
+func hashEmail(email string) string {
+	return hashSecret(strings.ToLower(strings.TrimSpace(email)))
+}
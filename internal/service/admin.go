@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+const (
+	// ListedGamesPerPage is how many games are listed on each page of an
+	// operator's search.
+	ListedGamesPerPage = 50
+	// AuditLogLength is how many of the latest audit log entries are
+	// shown to operators.
+	AuditLogLength = 50
+)
+
+// SearchGames lists a page of the games matching search for an operator.
+// Pages count from 1.
+func (s *service) SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	games, err := s.store.SearchGames(ctx, search, ListedGamesPerPage+1, (page-1)*ListedGamesPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := &wording.ListedGames{Games: games, Page: page}
+	if len(games) > ListedGamesPerPage {
+		listed.Games, listed.More = games[:ListedGamesPerPage], true
+	}
+
+	return listed, nil
+}
+
+// InspectGame fetches a game, including its answer and plays, for an
+// operator. Every look is recorded in the audit log.
+func (s *service) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	record, err := s.store.InspectGame(ctx, operator, adminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return record, err
+}
+
+// SetGameDisabled stops a game from being played, or lets it be played
+// again, and records it in the audit log. Players keep their plays.
+func (s *service) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	err := s.store.SetGameDisabled(ctx, operator, adminToken, disabled)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return err
+}
+
+// OperatorDeleteGame deletes a game and its attempts and records it in the
+// audit log.
+func (s *service) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	err := s.store.OperatorDeleteGame(ctx, operator, adminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return err
+}
+
+// AuditLog fetches the latest audit log entries, newest first.
+func (s *service) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	return s.store.AuditLog(ctx, limit)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// CreatedGamesPerPage is how many games are listed on each page of a
+// creator's games.
+const CreatedGamesPerPage = 20
+
+// CreatedGames lists a page of the games a player created, newest first.
+// Pages count from 1.
+func (s *service) CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	// Asking for one more than fits on the page tells whether there's
+	// another page.
+	games, err := s.store.CreatedGames(ctx, creator, CreatedGamesPerPage+1, (page-1)*CreatedGamesPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &wording.CreatedGames{Games: games, Page: page}
+	if len(games) > CreatedGamesPerPage {
+		created.Games, created.More = games[:CreatedGamesPerPage], true
+	}
+
+	return created, nil
+}
+
+// DeleteCreatedGames deletes those of the games, and the attempts made
+// against them, that the player created. It returns how many were
+// deleted.
+func (s *service) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	if creator == "" || len(adminTokens) == 0 {
+		return 0, nil
+	}
+
+	return s.store.DeleteCreatedGames(ctx, creator, adminTokens)
+}
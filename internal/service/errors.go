@@ -13,4 +13,22 @@ var (
 	// ErrCannotContinue indicates the player has no more guesses
 	// or they have already won.
 	ErrCannotContinue = errors.New("game is over")
+
+	// ErrConflict means the resource clashes with a different one that
+	// already exists.
+	ErrConflict = errors.New("conflict")
+
+	// ErrMailDisabled means there's no way to send mail, so players can't
+	// log in or recover their games.
+	ErrMailDisabled = errors.New("mail is disabled")
+
+	// ErrGameDisabled means an operator has stopped the game from being
+	// played.
+	ErrGameDisabled = errors.New("game is disabled")
+
+	// ErrGameNotOpen means the game is scheduled to open later.
+	ErrGameNotOpen = errors.New("game has not opened yet")
+
+	// ErrGameClosed means the game's scheduled close has passed.
+	ErrGameClosed = errors.New("game is closed")
 )
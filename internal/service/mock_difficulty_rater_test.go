@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package service
+
+import (
+	wording "github.com/connorkuehl/wording/internal/wording"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDifficultyRater is an autogenerated mock type for the DifficultyRater type
+type MockDifficultyRater struct {
+	mock.Mock
+}
+
+type MockDifficultyRater_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDifficultyRater) EXPECT() *MockDifficultyRater_Expecter {
+	return &MockDifficultyRater_Expecter{mock: &_m.Mock}
+}
+
+// RateDifficulty provides a mock function with given fields: answer
+func (_m *MockDifficultyRater) RateDifficulty(answer string) wording.Difficulty {
+	ret := _m.Called(answer)
+
+	var r0 wording.Difficulty
+	if rf, ok := ret.Get(0).(func(string) wording.Difficulty); ok {
+		r0 = rf(answer)
+	} else {
+		r0 = ret.Get(0).(wording.Difficulty)
+	}
+
+	return r0
+}
+
+// MockDifficultyRater_RateDifficulty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RateDifficulty'
+type MockDifficultyRater_RateDifficulty_Call struct {
+	*mock.Call
+}
+
+// RateDifficulty is a helper method to define mock.On call
+//  - answer string
+func (_e *MockDifficultyRater_Expecter) RateDifficulty(answer interface{}) *MockDifficultyRater_RateDifficulty_Call {
+	return &MockDifficultyRater_RateDifficulty_Call{Call: _e.mock.On("RateDifficulty", answer)}
+}
+
+func (_c *MockDifficultyRater_RateDifficulty_Call) Run(run func(answer string)) *MockDifficultyRater_RateDifficulty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockDifficultyRater_RateDifficulty_Call) Return(_a0 wording.Difficulty) *MockDifficultyRater_RateDifficulty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewMockDifficultyRater interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockDifficultyRater creates a new instance of MockDifficultyRater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockDifficultyRater(t mockConstructorTestingTNewMockDifficultyRater) *MockDifficultyRater {
+	mock := &MockDifficultyRater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
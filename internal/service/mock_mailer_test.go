@@ -0,0 +1,118 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMailer is an autogenerated mock type for the Mailer type
+type MockMailer struct {
+	mock.Mock
+}
+
+type MockMailer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMailer) EXPECT() *MockMailer_Expecter {
+	return &MockMailer_Expecter{mock: &_m.Mock}
+}
+
+// SendLoginLink provides a mock function with given fields: ctx, to, token, expiresIn
+func (_m *MockMailer) SendLoginLink(ctx context.Context, to string, token string, expiresIn time.Duration) error {
+	ret := _m.Called(ctx, to, token, expiresIn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, to, token, expiresIn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMailer_SendLoginLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLoginLink'
+type MockMailer_SendLoginLink_Call struct {
+	*mock.Call
+}
+
+// SendLoginLink is a helper method to define mock.On call
+//  - ctx context.Context
+//  - to string
+//  - token string
+//  - expiresIn time.Duration
+func (_e *MockMailer_Expecter) SendLoginLink(ctx interface{}, to interface{}, token interface{}, expiresIn interface{}) *MockMailer_SendLoginLink_Call {
+	return &MockMailer_SendLoginLink_Call{Call: _e.mock.On("SendLoginLink", ctx, to, token, expiresIn)}
+}
+
+func (_c *MockMailer_SendLoginLink_Call) Run(run func(ctx context.Context, to string, token string, expiresIn time.Duration)) *MockMailer_SendLoginLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMailer_SendLoginLink_Call) Return(_a0 error) *MockMailer_SendLoginLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SendRecoveryLink provides a mock function with given fields: ctx, to, token, expiresIn
+func (_m *MockMailer) SendRecoveryLink(ctx context.Context, to string, token string, expiresIn time.Duration) error {
+	ret := _m.Called(ctx, to, token, expiresIn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, to, token, expiresIn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMailer_SendRecoveryLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendRecoveryLink'
+type MockMailer_SendRecoveryLink_Call struct {
+	*mock.Call
+}
+
+// SendRecoveryLink is a helper method to define mock.On call
+//  - ctx context.Context
+//  - to string
+//  - token string
+//  - expiresIn time.Duration
+func (_e *MockMailer_Expecter) SendRecoveryLink(ctx interface{}, to interface{}, token interface{}, expiresIn interface{}) *MockMailer_SendRecoveryLink_Call {
+	return &MockMailer_SendRecoveryLink_Call{Call: _e.mock.On("SendRecoveryLink", ctx, to, token, expiresIn)}
+}
+
+func (_c *MockMailer_SendRecoveryLink_Call) Run(run func(ctx context.Context, to string, token string, expiresIn time.Duration)) *MockMailer_SendRecoveryLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMailer_SendRecoveryLink_Call) Return(_a0 error) *MockMailer_SendRecoveryLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewMockMailer interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockMailer creates a new instance of MockMailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockMailer(t mockConstructorTestingTNewMockMailer) *MockMailer {
+	mock := &MockMailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,7 @@ package service
 
 import (
 	context "context"
+	time "time"
 
 	wording "github.com/connorkuehl/wording/internal/wording"
 	mock "github.com/stretchr/testify/mock"
@@ -22,13 +23,107 @@ func (_m *MockStore) EXPECT() *MockStore_Expecter {
 	return &MockStore_Expecter{mock: &_m.Mock}
 }
 
-// CreateGame provides a mock function with given fields: ctx, adminToken, token, answer, guessLimit
-func (_m *MockStore) CreateGame(ctx context.Context, adminToken string, token string, answer string, guessLimit int) (*wording.Game, error) {
-	ret := _m.Called(ctx, adminToken, token, answer, guessLimit)
+// AccountSession provides a mock function with given fields: ctx, sessionHash
+func (_m *MockStore) AccountSession(ctx context.Context, sessionHash string) (*wording.Account, error) {
+	ret := _m.Called(ctx, sessionHash)
+
+	var r0 *wording.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Account); ok {
+		r0 = rf(ctx, sessionHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sessionHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_AccountSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountSession'
+type MockStore_AccountSession_Call struct {
+	*mock.Call
+}
+
+// AccountSession is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sessionHash string
+func (_e *MockStore_Expecter) AccountSession(ctx interface{}, sessionHash interface{}) *MockStore_AccountSession_Call {
+	return &MockStore_AccountSession_Call{Call: _e.mock.On("AccountSession", ctx, sessionHash)}
+}
+
+func (_c *MockStore_AccountSession_Call) Run(run func(ctx context.Context, sessionHash string)) *MockStore_AccountSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_AccountSession_Call) Return(_a0 *wording.Account, _a1 error) *MockStore_AccountSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// AuditLog provides a mock function with given fields: ctx, limit
+func (_m *MockStore) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []wording.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int) []wording.AuditEntry); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_AuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditLog'
+type MockStore_AuditLog_Call struct {
+	*mock.Call
+}
+
+// AuditLog is a helper method to define mock.On call
+//  - ctx context.Context
+//  - limit int
+func (_e *MockStore_Expecter) AuditLog(ctx interface{}, limit interface{}) *MockStore_AuditLog_Call {
+	return &MockStore_AuditLog_Call{Call: _e.mock.On("AuditLog", ctx, limit)}
+}
+
+func (_c *MockStore_AuditLog_Call) Run(run func(ctx context.Context, limit int)) *MockStore_AuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_AuditLog_Call) Return(_a0 []wording.AuditEntry, _a1 error) *MockStore_AuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// CreateGame provides a mock function with given fields: ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator
+func (_m *MockStore) CreateGame(ctx context.Context, adminToken string, token string, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	ret := _m.Called(ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)
 
 	var r0 *wording.Game
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) *wording.Game); ok {
-		r0 = rf(ctx, adminToken, token, answer, guessLimit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, wording.Difficulty, wording.GameSettings, string) *wording.Game); ok {
+		r0 = rf(ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*wording.Game)
@@ -36,8 +131,8 @@ func (_m *MockStore) CreateGame(ctx context.Context, adminToken string, token st
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
-		r1 = rf(ctx, adminToken, token, answer, guessLimit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int, wording.Difficulty, wording.GameSettings, string) error); ok {
+		r1 = rf(ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +151,16 @@ type MockStore_CreateGame_Call struct {
 //  - token string
 //  - answer string
 //  - guessLimit int
-func (_e *MockStore_Expecter) CreateGame(ctx interface{}, adminToken interface{}, token interface{}, answer interface{}, guessLimit interface{}) *MockStore_CreateGame_Call {
-	return &MockStore_CreateGame_Call{Call: _e.mock.On("CreateGame", ctx, adminToken, token, answer, guessLimit)}
+//  - difficulty wording.Difficulty
+//  - settings wording.GameSettings
+//  - creator string
+func (_e *MockStore_Expecter) CreateGame(ctx interface{}, adminToken interface{}, token interface{}, answer interface{}, guessLimit interface{}, difficulty interface{}, settings interface{}, creator interface{}) *MockStore_CreateGame_Call {
+	return &MockStore_CreateGame_Call{Call: _e.mock.On("CreateGame", ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)}
 }
 
-func (_c *MockStore_CreateGame_Call) Run(run func(ctx context.Context, adminToken string, token string, answer string, guessLimit int)) *MockStore_CreateGame_Call {
+func (_c *MockStore_CreateGame_Call) Run(run func(ctx context.Context, adminToken string, token string, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string)) *MockStore_CreateGame_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int), args[5].(wording.Difficulty), args[6].(wording.GameSettings), args[7].(string))
 	})
 	return _c
 }
@@ -72,13 +170,13 @@ func (_c *MockStore_CreateGame_Call) Return(_a0 *wording.Game, _a1 error) *MockS
 	return _c
 }
 
-// DeleteGame provides a mock function with given fields: ctx, adminToken
-func (_m *MockStore) DeleteGame(ctx context.Context, adminToken string) error {
-	ret := _m.Called(ctx, adminToken)
+// CreateLoginToken provides a mock function with given fields: ctx, tokenHash, emailHash, expiresAt
+func (_m *MockStore) CreateLoginToken(ctx context.Context, tokenHash string, emailHash string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, tokenHash, emailHash, expiresAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, adminToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, tokenHash, emailHash, expiresAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -86,46 +184,88 @@ func (_m *MockStore) DeleteGame(ctx context.Context, adminToken string) error {
 	return r0
 }
 
-// MockStore_DeleteGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGame'
-type MockStore_DeleteGame_Call struct {
+// MockStore_CreateLoginToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLoginToken'
+type MockStore_CreateLoginToken_Call struct {
 	*mock.Call
 }
 
-// DeleteGame is a helper method to define mock.On call
+// CreateLoginToken is a helper method to define mock.On call
 //  - ctx context.Context
-//  - adminToken string
-func (_e *MockStore_Expecter) DeleteGame(ctx interface{}, adminToken interface{}) *MockStore_DeleteGame_Call {
-	return &MockStore_DeleteGame_Call{Call: _e.mock.On("DeleteGame", ctx, adminToken)}
+//  - tokenHash string
+//  - emailHash string
+//  - expiresAt time.Time
+func (_e *MockStore_Expecter) CreateLoginToken(ctx interface{}, tokenHash interface{}, emailHash interface{}, expiresAt interface{}) *MockStore_CreateLoginToken_Call {
+	return &MockStore_CreateLoginToken_Call{Call: _e.mock.On("CreateLoginToken", ctx, tokenHash, emailHash, expiresAt)}
 }
 
-func (_c *MockStore_DeleteGame_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_DeleteGame_Call {
+func (_c *MockStore_CreateLoginToken_Call) Run(run func(ctx context.Context, tokenHash string, emailHash string, expiresAt time.Time)) *MockStore_CreateLoginToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_DeleteGame_Call) Return(_a0 error) *MockStore_DeleteGame_Call {
+func (_c *MockStore_CreateLoginToken_Call) Return(_a0 error) *MockStore_CreateLoginToken_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-// Game provides a mock function with given fields: ctx, adminToken
-func (_m *MockStore) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
-	ret := _m.Called(ctx, adminToken)
+// CreateRecoveryToken provides a mock function with given fields: ctx, tokenHash, emailHash, expiresAt
+func (_m *MockStore) CreateRecoveryToken(ctx context.Context, tokenHash string, emailHash string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, tokenHash, emailHash, expiresAt)
 
-	var r0 *wording.Game
-	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Game); ok {
-		r0 = rf(ctx, adminToken)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, tokenHash, emailHash, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateRecoveryToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecoveryToken'
+type MockStore_CreateRecoveryToken_Call struct {
+	*mock.Call
+}
+
+// CreateRecoveryToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - tokenHash string
+//  - emailHash string
+//  - expiresAt time.Time
+func (_e *MockStore_Expecter) CreateRecoveryToken(ctx interface{}, tokenHash interface{}, emailHash interface{}, expiresAt interface{}) *MockStore_CreateRecoveryToken_Call {
+	return &MockStore_CreateRecoveryToken_Call{Call: _e.mock.On("CreateRecoveryToken", ctx, tokenHash, emailHash, expiresAt)}
+}
+
+func (_c *MockStore_CreateRecoveryToken_Call) Run(run func(ctx context.Context, tokenHash string, emailHash string, expiresAt time.Time)) *MockStore_CreateRecoveryToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateRecoveryToken_Call) Return(_a0 error) *MockStore_CreateRecoveryToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// CreatedGames provides a mock function with given fields: ctx, creator, limit, offset
+func (_m *MockStore) CreatedGames(ctx context.Context, creator string, limit int, offset int) ([]wording.CreatedGame, error) {
+	ret := _m.Called(ctx, creator, limit, offset)
+
+	var r0 []wording.CreatedGame
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []wording.CreatedGame); ok {
+		r0 = rf(ctx, creator, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wording.Game)
+			r0 = ret.Get(0).([]wording.CreatedGame)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, adminToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, creator, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,46 +273,48 @@ func (_m *MockStore) Game(ctx context.Context, adminToken string) (*wording.Game
 	return r0, r1
 }
 
-// MockStore_Game_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Game'
-type MockStore_Game_Call struct {
+// MockStore_CreatedGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatedGames'
+type MockStore_CreatedGames_Call struct {
 	*mock.Call
 }
 
-// Game is a helper method to define mock.On call
+// CreatedGames is a helper method to define mock.On call
 //  - ctx context.Context
-//  - adminToken string
-func (_e *MockStore_Expecter) Game(ctx interface{}, adminToken interface{}) *MockStore_Game_Call {
-	return &MockStore_Game_Call{Call: _e.mock.On("Game", ctx, adminToken)}
+//  - creator string
+//  - limit int
+//  - offset int
+func (_e *MockStore_Expecter) CreatedGames(ctx interface{}, creator interface{}, limit interface{}, offset interface{}) *MockStore_CreatedGames_Call {
+	return &MockStore_CreatedGames_Call{Call: _e.mock.On("CreatedGames", ctx, creator, limit, offset)}
 }
 
-func (_c *MockStore_Game_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_Game_Call {
+func (_c *MockStore_CreatedGames_Call) Run(run func(ctx context.Context, creator string, limit int, offset int)) *MockStore_CreatedGames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockStore_Game_Call) Return(_a0 *wording.Game, _a1 error) *MockStore_Game_Call {
+func (_c *MockStore_CreatedGames_Call) Return(_a0 []wording.CreatedGame, _a1 error) *MockStore_CreatedGames_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// GameByToken provides a mock function with given fields: ctx, token
-func (_m *MockStore) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
-	ret := _m.Called(ctx, token)
+// DailyStats provides a mock function with given fields: ctx, since
+func (_m *MockStore) DailyStats(ctx context.Context, since time.Time) ([]wording.DailyStats, error) {
+	ret := _m.Called(ctx, since)
 
-	var r0 *wording.Game
-	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Game); ok {
-		r0 = rf(ctx, token)
+	var r0 []wording.DailyStats
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []wording.DailyStats); ok {
+		r0 = rf(ctx, since)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wording.Game)
+			r0 = ret.Get(0).([]wording.DailyStats)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -180,44 +322,44 @@ func (_m *MockStore) GameByToken(ctx context.Context, token string) (*wording.Ga
 	return r0, r1
 }
 
-// MockStore_GameByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameByToken'
-type MockStore_GameByToken_Call struct {
+// MockStore_DailyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DailyStats'
+type MockStore_DailyStats_Call struct {
 	*mock.Call
 }
 
-// GameByToken is a helper method to define mock.On call
+// DailyStats is a helper method to define mock.On call
 //  - ctx context.Context
-//  - token string
-func (_e *MockStore_Expecter) GameByToken(ctx interface{}, token interface{}) *MockStore_GameByToken_Call {
-	return &MockStore_GameByToken_Call{Call: _e.mock.On("GameByToken", ctx, token)}
+//  - since time.Time
+func (_e *MockStore_Expecter) DailyStats(ctx interface{}, since interface{}) *MockStore_DailyStats_Call {
+	return &MockStore_DailyStats_Call{Call: _e.mock.On("DailyStats", ctx, since)}
 }
 
-func (_c *MockStore_GameByToken_Call) Run(run func(ctx context.Context, token string)) *MockStore_GameByToken_Call {
+func (_c *MockStore_DailyStats_Call) Run(run func(ctx context.Context, since time.Time)) *MockStore_DailyStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_GameByToken_Call) Return(_a0 *wording.Game, _a1 error) *MockStore_GameByToken_Call {
+func (_c *MockStore_DailyStats_Call) Return(_a0 []wording.DailyStats, _a1 error) *MockStore_DailyStats_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// GameStats provides a mock function with given fields: ctx, adminToken
-func (_m *MockStore) GameStats(ctx context.Context, adminToken string) (wording.Stats, error) {
-	ret := _m.Called(ctx, adminToken)
+// DeleteCreatedGames provides a mock function with given fields: ctx, creator, adminTokens
+func (_m *MockStore) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	ret := _m.Called(ctx, creator, adminTokens)
 
-	var r0 wording.Stats
-	if rf, ok := ret.Get(0).(func(context.Context, string) wording.Stats); ok {
-		r0 = rf(ctx, adminToken)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) int); ok {
+		r0 = rf(ctx, creator, adminTokens)
 	} else {
-		r0 = ret.Get(0).(wording.Stats)
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, adminToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, creator, adminTokens)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -225,37 +367,38 @@ func (_m *MockStore) GameStats(ctx context.Context, adminToken string) (wording.
 	return r0, r1
 }
 
-// MockStore_GameStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameStats'
-type MockStore_GameStats_Call struct {
+// MockStore_DeleteCreatedGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCreatedGames'
+type MockStore_DeleteCreatedGames_Call struct {
 	*mock.Call
 }
 
-// GameStats is a helper method to define mock.On call
+// DeleteCreatedGames is a helper method to define mock.On call
 //  - ctx context.Context
-//  - adminToken string
-func (_e *MockStore_Expecter) GameStats(ctx interface{}, adminToken interface{}) *MockStore_GameStats_Call {
-	return &MockStore_GameStats_Call{Call: _e.mock.On("GameStats", ctx, adminToken)}
+//  - creator string
+//  - adminTokens []string
+func (_e *MockStore_Expecter) DeleteCreatedGames(ctx interface{}, creator interface{}, adminTokens interface{}) *MockStore_DeleteCreatedGames_Call {
+	return &MockStore_DeleteCreatedGames_Call{Call: _e.mock.On("DeleteCreatedGames", ctx, creator, adminTokens)}
 }
 
-func (_c *MockStore_GameStats_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_GameStats_Call {
+func (_c *MockStore_DeleteCreatedGames_Call) Run(run func(ctx context.Context, creator string, adminTokens []string)) *MockStore_DeleteCreatedGames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *MockStore_GameStats_Call) Return(_a0 wording.Stats, _a1 error) *MockStore_GameStats_Call {
+func (_c *MockStore_DeleteCreatedGames_Call) Return(_a0 int, _a1 error) *MockStore_DeleteCreatedGames_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// IncrementStats provides a mock function with given fields: ctx, stats
-func (_m *MockStore) IncrementStats(ctx context.Context, stats wording.IncrementStats) error {
-	ret := _m.Called(ctx, stats)
+// DeleteGame provides a mock function with given fields: ctx, adminToken
+func (_m *MockStore) DeleteGame(ctx context.Context, adminToken string) error {
+	ret := _m.Called(ctx, adminToken)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, wording.IncrementStats) error); ok {
-		r0 = rf(ctx, stats)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, adminToken)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -263,46 +406,44 @@ func (_m *MockStore) IncrementStats(ctx context.Context, stats wording.Increment
 	return r0
 }
 
-// MockStore_IncrementStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementStats'
-type MockStore_IncrementStats_Call struct {
+// MockStore_DeleteGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGame'
+type MockStore_DeleteGame_Call struct {
 	*mock.Call
 }
 
-// IncrementStats is a helper method to define mock.On call
+// DeleteGame is a helper method to define mock.On call
 //  - ctx context.Context
-//  - stats wording.IncrementStats
-func (_e *MockStore_Expecter) IncrementStats(ctx interface{}, stats interface{}) *MockStore_IncrementStats_Call {
-	return &MockStore_IncrementStats_Call{Call: _e.mock.On("IncrementStats", ctx, stats)}
+//  - adminToken string
+func (_e *MockStore_Expecter) DeleteGame(ctx interface{}, adminToken interface{}) *MockStore_DeleteGame_Call {
+	return &MockStore_DeleteGame_Call{Call: _e.mock.On("DeleteGame", ctx, adminToken)}
 }
 
-func (_c *MockStore_IncrementStats_Call) Run(run func(ctx context.Context, stats wording.IncrementStats)) *MockStore_IncrementStats_Call {
+func (_c *MockStore_DeleteGame_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_DeleteGame_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(wording.IncrementStats))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_IncrementStats_Call) Return(_a0 error) *MockStore_IncrementStats_Call {
+func (_c *MockStore_DeleteGame_Call) Return(_a0 error) *MockStore_DeleteGame_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-// Plays provides a mock function with given fields: ctx, gameToken, playerToken
-func (_m *MockStore) Plays(ctx context.Context, gameToken string, playerToken string) (*wording.Plays, error) {
-	ret := _m.Called(ctx, gameToken, playerToken)
+// DeletePlayerGames provides a mock function with given fields: ctx, playerToken
+func (_m *MockStore) DeletePlayerGames(ctx context.Context, playerToken string) (int, error) {
+	ret := _m.Called(ctx, playerToken)
 
-	var r0 *wording.Plays
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *wording.Plays); ok {
-		r0 = rf(ctx, gameToken, playerToken)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, playerToken)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wording.Plays)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, gameToken, playerToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, playerToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -310,38 +451,37 @@ func (_m *MockStore) Plays(ctx context.Context, gameToken string, playerToken st
 	return r0, r1
 }
 
-// MockStore_Plays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Plays'
-type MockStore_Plays_Call struct {
+// MockStore_DeletePlayerGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePlayerGames'
+type MockStore_DeletePlayerGames_Call struct {
 	*mock.Call
 }
 
-// Plays is a helper method to define mock.On call
+// DeletePlayerGames is a helper method to define mock.On call
 //  - ctx context.Context
-//  - gameToken string
 //  - playerToken string
-func (_e *MockStore_Expecter) Plays(ctx interface{}, gameToken interface{}, playerToken interface{}) *MockStore_Plays_Call {
-	return &MockStore_Plays_Call{Call: _e.mock.On("Plays", ctx, gameToken, playerToken)}
+func (_e *MockStore_Expecter) DeletePlayerGames(ctx interface{}, playerToken interface{}) *MockStore_DeletePlayerGames_Call {
+	return &MockStore_DeletePlayerGames_Call{Call: _e.mock.On("DeletePlayerGames", ctx, playerToken)}
 }
 
-func (_c *MockStore_Plays_Call) Run(run func(ctx context.Context, gameToken string, playerToken string)) *MockStore_Plays_Call {
+func (_c *MockStore_DeletePlayerGames_Call) Run(run func(ctx context.Context, playerToken string)) *MockStore_DeletePlayerGames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_Plays_Call) Return(_a0 *wording.Plays, _a1 error) *MockStore_Plays_Call {
+func (_c *MockStore_DeletePlayerGames_Call) Return(_a0 int, _a1 error) *MockStore_DeletePlayerGames_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// PutPlays provides a mock function with given fields: ctx, gameToken, playerToken, plays
-func (_m *MockStore) PutPlays(ctx context.Context, gameToken string, playerToken string, plays *wording.Plays) error {
-	ret := _m.Called(ctx, gameToken, playerToken, plays)
+// DeleteSession provides a mock function with given fields: ctx, sessionHash
+func (_m *MockStore) DeleteSession(ctx context.Context, sessionHash string) error {
+	ret := _m.Called(ctx, sessionHash)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *wording.Plays) error); ok {
-		r0 = rf(ctx, gameToken, playerToken, plays)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sessionHash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -349,72 +489,1073 @@ func (_m *MockStore) PutPlays(ctx context.Context, gameToken string, playerToken
 	return r0
 }
 
-// MockStore_PutPlays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutPlays'
-type MockStore_PutPlays_Call struct {
+// MockStore_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockStore_DeleteSession_Call struct {
 	*mock.Call
 }
 
-// PutPlays is a helper method to define mock.On call
+// DeleteSession is a helper method to define mock.On call
 //  - ctx context.Context
-//  - gameToken string
-//  - playerToken string
-//  - plays *wording.Plays
-func (_e *MockStore_Expecter) PutPlays(ctx interface{}, gameToken interface{}, playerToken interface{}, plays interface{}) *MockStore_PutPlays_Call {
-	return &MockStore_PutPlays_Call{Call: _e.mock.On("PutPlays", ctx, gameToken, playerToken, plays)}
+//  - sessionHash string
+func (_e *MockStore_Expecter) DeleteSession(ctx interface{}, sessionHash interface{}) *MockStore_DeleteSession_Call {
+	return &MockStore_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, sessionHash)}
 }
 
-func (_c *MockStore_PutPlays_Call) Run(run func(ctx context.Context, gameToken string, playerToken string, plays *wording.Plays)) *MockStore_PutPlays_Call {
+func (_c *MockStore_DeleteSession_Call) Run(run func(ctx context.Context, sessionHash string)) *MockStore_DeleteSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*wording.Plays))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_PutPlays_Call) Return(_a0 error) *MockStore_PutPlays_Call {
+func (_c *MockStore_DeleteSession_Call) Return(_a0 error) *MockStore_DeleteSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-// Stats provides a mock function with given fields: ctx
-func (_m *MockStore) Stats(ctx context.Context) (wording.Stats, error) {
-	ret := _m.Called(ctx)
-
-	var r0 wording.Stats
-	if rf, ok := ret.Get(0).(func(context.Context) wording.Stats); ok {
-		r0 = rf(ctx)
-	} else {
-		r0 = ret.Get(0).(wording.Stats)
-	}
+// ExportGames provides a mock function with given fields: ctx, fn
+func (_m *MockStore) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	ret := _m.Called(ctx, fn)
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*wording.GameRecord) error) error); ok {
+		r0 = rf(ctx, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStore_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
-type MockStore_Stats_Call struct {
+// MockStore_ExportGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportGames'
+type MockStore_ExportGames_Call struct {
 	*mock.Call
 }
 
-// Stats is a helper method to define mock.On call
+// ExportGames is a helper method to define mock.On call
 //  - ctx context.Context
-func (_e *MockStore_Expecter) Stats(ctx interface{}) *MockStore_Stats_Call {
-	return &MockStore_Stats_Call{Call: _e.mock.On("Stats", ctx)}
+//  - fn func(*wording.GameRecord) error
+func (_e *MockStore_Expecter) ExportGames(ctx interface{}, fn interface{}) *MockStore_ExportGames_Call {
+	return &MockStore_ExportGames_Call{Call: _e.mock.On("ExportGames", ctx, fn)}
 }
 
-func (_c *MockStore_Stats_Call) Run(run func(ctx context.Context)) *MockStore_Stats_Call {
+func (_c *MockStore_ExportGames_Call) Run(run func(ctx context.Context, fn func(*wording.GameRecord) error)) *MockStore_ExportGames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(func(*wording.GameRecord) error))
 	})
 	return _c
 }
 
-func (_c *MockStore_Stats_Call) Return(_a0 wording.Stats, _a1 error) *MockStore_Stats_Call {
+func (_c *MockStore_ExportGames_Call) Return(_a0 error) *MockStore_ExportGames_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// Game provides a mock function with given fields: ctx, adminToken
+func (_m *MockStore) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
+	ret := _m.Called(ctx, adminToken)
+
+	var r0 *wording.Game
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Game); ok {
+		r0 = rf(ctx, adminToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Game)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Game_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Game'
+type MockStore_Game_Call struct {
+	*mock.Call
+}
+
+// Game is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+func (_e *MockStore_Expecter) Game(ctx interface{}, adminToken interface{}) *MockStore_Game_Call {
+	return &MockStore_Game_Call{Call: _e.mock.On("Game", ctx, adminToken)}
+}
+
+func (_c *MockStore_Game_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_Game_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Game_Call) Return(_a0 *wording.Game, _a1 error) *MockStore_Game_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GameByToken provides a mock function with given fields: ctx, token
+func (_m *MockStore) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *wording.Game
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.Game); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Game)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GameByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameByToken'
+type MockStore_GameByToken_Call struct {
+	*mock.Call
+}
+
+// GameByToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - token string
+func (_e *MockStore_Expecter) GameByToken(ctx interface{}, token interface{}) *MockStore_GameByToken_Call {
+	return &MockStore_GameByToken_Call{Call: _e.mock.On("GameByToken", ctx, token)}
+}
+
+func (_c *MockStore_GameByToken_Call) Run(run func(ctx context.Context, token string)) *MockStore_GameByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GameByToken_Call) Return(_a0 *wording.Game, _a1 error) *MockStore_GameByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GameDailyStats provides a mock function with given fields: ctx, adminToken, since
+func (_m *MockStore) GameDailyStats(ctx context.Context, adminToken string, since time.Time) ([]wording.DailyStats, error) {
+	ret := _m.Called(ctx, adminToken, since)
+
+	var r0 []wording.DailyStats
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []wording.DailyStats); ok {
+		r0 = rf(ctx, adminToken, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.DailyStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, adminToken, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GameDailyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameDailyStats'
+type MockStore_GameDailyStats_Call struct {
+	*mock.Call
+}
+
+// GameDailyStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - since time.Time
+func (_e *MockStore_Expecter) GameDailyStats(ctx interface{}, adminToken interface{}, since interface{}) *MockStore_GameDailyStats_Call {
+	return &MockStore_GameDailyStats_Call{Call: _e.mock.On("GameDailyStats", ctx, adminToken, since)}
+}
+
+func (_c *MockStore_GameDailyStats_Call) Run(run func(ctx context.Context, adminToken string, since time.Time)) *MockStore_GameDailyStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_GameDailyStats_Call) Return(_a0 []wording.DailyStats, _a1 error) *MockStore_GameDailyStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GameRecord provides a mock function with given fields: ctx, adminToken
+func (_m *MockStore) GameRecord(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	ret := _m.Called(ctx, adminToken)
+
+	var r0 *wording.GameRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wording.GameRecord); ok {
+		r0 = rf(ctx, adminToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.GameRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GameRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameRecord'
+type MockStore_GameRecord_Call struct {
+	*mock.Call
+}
+
+// GameRecord is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+func (_e *MockStore_Expecter) GameRecord(ctx interface{}, adminToken interface{}) *MockStore_GameRecord_Call {
+	return &MockStore_GameRecord_Call{Call: _e.mock.On("GameRecord", ctx, adminToken)}
+}
+
+func (_c *MockStore_GameRecord_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_GameRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GameRecord_Call) Return(_a0 *wording.GameRecord, _a1 error) *MockStore_GameRecord_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GameStats provides a mock function with given fields: ctx, adminToken
+func (_m *MockStore) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	ret := _m.Called(ctx, adminToken)
+
+	var r0 wording.GameStats
+	if rf, ok := ret.Get(0).(func(context.Context, string) wording.GameStats); ok {
+		r0 = rf(ctx, adminToken)
+	} else {
+		r0 = ret.Get(0).(wording.GameStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GameStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GameStats'
+type MockStore_GameStats_Call struct {
+	*mock.Call
+}
+
+// GameStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+func (_e *MockStore_Expecter) GameStats(ctx interface{}, adminToken interface{}) *MockStore_GameStats_Call {
+	return &MockStore_GameStats_Call{Call: _e.mock.On("GameStats", ctx, adminToken)}
+}
+
+func (_c *MockStore_GameStats_Call) Run(run func(ctx context.Context, adminToken string)) *MockStore_GameStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GameStats_Call) Return(_a0 wording.GameStats, _a1 error) *MockStore_GameStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ImportGame provides a mock function with given fields: ctx, record
+func (_m *MockStore) ImportGame(ctx context.Context, record *wording.GameRecord) error {
+	ret := _m.Called(ctx, record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *wording.GameRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_ImportGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportGame'
+type MockStore_ImportGame_Call struct {
+	*mock.Call
+}
+
+// ImportGame is a helper method to define mock.On call
+//  - ctx context.Context
+//  - record *wording.GameRecord
+func (_e *MockStore_Expecter) ImportGame(ctx interface{}, record interface{}) *MockStore_ImportGame_Call {
+	return &MockStore_ImportGame_Call{Call: _e.mock.On("ImportGame", ctx, record)}
+}
+
+func (_c *MockStore_ImportGame_Call) Run(run func(ctx context.Context, record *wording.GameRecord)) *MockStore_ImportGame_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*wording.GameRecord))
+	})
+	return _c
+}
+
+func (_c *MockStore_ImportGame_Call) Return(_a0 error) *MockStore_ImportGame_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// IncrementStats provides a mock function with given fields: ctx, stats
+func (_m *MockStore) IncrementStats(ctx context.Context, stats wording.IncrementStats) error {
+	ret := _m.Called(ctx, stats)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, wording.IncrementStats) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_IncrementStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementStats'
+type MockStore_IncrementStats_Call struct {
+	*mock.Call
+}
+
+// IncrementStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - stats wording.IncrementStats
+func (_e *MockStore_Expecter) IncrementStats(ctx interface{}, stats interface{}) *MockStore_IncrementStats_Call {
+	return &MockStore_IncrementStats_Call{Call: _e.mock.On("IncrementStats", ctx, stats)}
+}
+
+func (_c *MockStore_IncrementStats_Call) Run(run func(ctx context.Context, stats wording.IncrementStats)) *MockStore_IncrementStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(wording.IncrementStats))
+	})
+	return _c
+}
+
+func (_c *MockStore_IncrementStats_Call) Return(_a0 error) *MockStore_IncrementStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// InspectGame provides a mock function with given fields: ctx, operator, adminToken
+func (_m *MockStore) InspectGame(ctx context.Context, operator string, adminToken string) (*wording.GameRecord, error) {
+	ret := _m.Called(ctx, operator, adminToken)
+
+	var r0 *wording.GameRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *wording.GameRecord); ok {
+		r0 = rf(ctx, operator, adminToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.GameRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, operator, adminToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_InspectGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectGame'
+type MockStore_InspectGame_Call struct {
+	*mock.Call
+}
+
+// InspectGame is a helper method to define mock.On call
+//  - ctx context.Context
+//  - operator string
+//  - adminToken string
+func (_e *MockStore_Expecter) InspectGame(ctx interface{}, operator interface{}, adminToken interface{}) *MockStore_InspectGame_Call {
+	return &MockStore_InspectGame_Call{Call: _e.mock.On("InspectGame", ctx, operator, adminToken)}
+}
+
+func (_c *MockStore_InspectGame_Call) Run(run func(ctx context.Context, operator string, adminToken string)) *MockStore_InspectGame_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_InspectGame_Call) Return(_a0 *wording.GameRecord, _a1 error) *MockStore_InspectGame_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// OperatorDeleteGame provides a mock function with given fields: ctx, operator, adminToken
+func (_m *MockStore) OperatorDeleteGame(ctx context.Context, operator string, adminToken string) error {
+	ret := _m.Called(ctx, operator, adminToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, operator, adminToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_OperatorDeleteGame_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OperatorDeleteGame'
+type MockStore_OperatorDeleteGame_Call struct {
+	*mock.Call
+}
+
+// OperatorDeleteGame is a helper method to define mock.On call
+//  - ctx context.Context
+//  - operator string
+//  - adminToken string
+func (_e *MockStore_Expecter) OperatorDeleteGame(ctx interface{}, operator interface{}, adminToken interface{}) *MockStore_OperatorDeleteGame_Call {
+	return &MockStore_OperatorDeleteGame_Call{Call: _e.mock.On("OperatorDeleteGame", ctx, operator, adminToken)}
+}
+
+func (_c *MockStore_OperatorDeleteGame_Call) Run(run func(ctx context.Context, operator string, adminToken string)) *MockStore_OperatorDeleteGame_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_OperatorDeleteGame_Call) Return(_a0 error) *MockStore_OperatorDeleteGame_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// PlayerGames provides a mock function with given fields: ctx, playerToken
+func (_m *MockStore) PlayerGames(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	ret := _m.Called(ctx, playerToken)
+
+	var r0 []wording.PlayerGame
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wording.PlayerGame); ok {
+		r0 = rf(ctx, playerToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.PlayerGame)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_PlayerGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlayerGames'
+type MockStore_PlayerGames_Call struct {
+	*mock.Call
+}
+
+// PlayerGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - playerToken string
+func (_e *MockStore_Expecter) PlayerGames(ctx interface{}, playerToken interface{}) *MockStore_PlayerGames_Call {
+	return &MockStore_PlayerGames_Call{Call: _e.mock.On("PlayerGames", ctx, playerToken)}
+}
+
+func (_c *MockStore_PlayerGames_Call) Run(run func(ctx context.Context, playerToken string)) *MockStore_PlayerGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_PlayerGames_Call) Return(_a0 []wording.PlayerGame, _a1 error) *MockStore_PlayerGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Plays provides a mock function with given fields: ctx, gameToken, playerToken
+func (_m *MockStore) Plays(ctx context.Context, gameToken string, playerToken string) (*wording.Plays, error) {
+	ret := _m.Called(ctx, gameToken, playerToken)
+
+	var r0 *wording.Plays
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *wording.Plays); ok {
+		r0 = rf(ctx, gameToken, playerToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Plays)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, gameToken, playerToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Plays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Plays'
+type MockStore_Plays_Call struct {
+	*mock.Call
+}
+
+// Plays is a helper method to define mock.On call
+//  - ctx context.Context
+//  - gameToken string
+//  - playerToken string
+func (_e *MockStore_Expecter) Plays(ctx interface{}, gameToken interface{}, playerToken interface{}) *MockStore_Plays_Call {
+	return &MockStore_Plays_Call{Call: _e.mock.On("Plays", ctx, gameToken, playerToken)}
+}
+
+func (_c *MockStore_Plays_Call) Run(run func(ctx context.Context, gameToken string, playerToken string)) *MockStore_Plays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Plays_Call) Return(_a0 *wording.Plays, _a1 error) *MockStore_Plays_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// PruneGames provides a mock function with given fields: ctx, idleSince
+func (_m *MockStore) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	ret := _m.Called(ctx, idleSince)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, idleSince)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, idleSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_PruneGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneGames'
+type MockStore_PruneGames_Call struct {
+	*mock.Call
+}
+
+// PruneGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - idleSince time.Time
+func (_e *MockStore_Expecter) PruneGames(ctx interface{}, idleSince interface{}) *MockStore_PruneGames_Call {
+	return &MockStore_PruneGames_Call{Call: _e.mock.On("PruneGames", ctx, idleSince)}
+}
+
+func (_c *MockStore_PruneGames_Call) Run(run func(ctx context.Context, idleSince time.Time)) *MockStore_PruneGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_PruneGames_Call) Return(_a0 int, _a1 error) *MockStore_PruneGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// PutPlays provides a mock function with given fields: ctx, gameToken, playerToken, plays, outcome
+func (_m *MockStore) PutPlays(ctx context.Context, gameToken string, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome) error {
+	ret := _m.Called(ctx, gameToken, playerToken, plays, outcome)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *wording.Plays, wording.PlayOutcome) error); ok {
+		r0 = rf(ctx, gameToken, playerToken, plays, outcome)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_PutPlays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutPlays'
+type MockStore_PutPlays_Call struct {
+	*mock.Call
+}
+
+// PutPlays is a helper method to define mock.On call
+//  - ctx context.Context
+//  - gameToken string
+//  - playerToken string
+//  - plays *wording.Plays
+//  - outcome wording.PlayOutcome
+func (_e *MockStore_Expecter) PutPlays(ctx interface{}, gameToken interface{}, playerToken interface{}, plays interface{}, outcome interface{}) *MockStore_PutPlays_Call {
+	return &MockStore_PutPlays_Call{Call: _e.mock.On("PutPlays", ctx, gameToken, playerToken, plays, outcome)}
+}
+
+func (_c *MockStore_PutPlays_Call) Run(run func(ctx context.Context, gameToken string, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome)) *MockStore_PutPlays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*wording.Plays), args[4].(wording.PlayOutcome))
+	})
+	return _c
+}
+
+func (_c *MockStore_PutPlays_Call) Return(_a0 error) *MockStore_PutPlays_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// RecoverableGames provides a mock function with given fields: ctx, emailHash
+func (_m *MockStore) RecoverableGames(ctx context.Context, emailHash string) ([]wording.Game, error) {
+	ret := _m.Called(ctx, emailHash)
+
+	var r0 []wording.Game
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wording.Game); ok {
+		r0 = rf(ctx, emailHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.Game)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, emailHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_RecoverableGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverableGames'
+type MockStore_RecoverableGames_Call struct {
+	*mock.Call
+}
+
+// RecoverableGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - emailHash string
+func (_e *MockStore_Expecter) RecoverableGames(ctx interface{}, emailHash interface{}) *MockStore_RecoverableGames_Call {
+	return &MockStore_RecoverableGames_Call{Call: _e.mock.On("RecoverableGames", ctx, emailHash)}
+}
+
+func (_c *MockStore_RecoverableGames_Call) Run(run func(ctx context.Context, emailHash string)) *MockStore_RecoverableGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_RecoverableGames_Call) Return(_a0 []wording.Game, _a1 error) *MockStore_RecoverableGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RotateAdminToken provides a mock function with given fields: ctx, adminToken, newAdminToken
+func (_m *MockStore) RotateAdminToken(ctx context.Context, adminToken string, newAdminToken string) error {
+	ret := _m.Called(ctx, adminToken, newAdminToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, adminToken, newAdminToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_RotateAdminToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAdminToken'
+type MockStore_RotateAdminToken_Call struct {
+	*mock.Call
+}
+
+// RotateAdminToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - newAdminToken string
+func (_e *MockStore_Expecter) RotateAdminToken(ctx interface{}, adminToken interface{}, newAdminToken interface{}) *MockStore_RotateAdminToken_Call {
+	return &MockStore_RotateAdminToken_Call{Call: _e.mock.On("RotateAdminToken", ctx, adminToken, newAdminToken)}
+}
+
+func (_c *MockStore_RotateAdminToken_Call) Run(run func(ctx context.Context, adminToken string, newAdminToken string)) *MockStore_RotateAdminToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_RotateAdminToken_Call) Return(_a0 error) *MockStore_RotateAdminToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SearchGames provides a mock function with given fields: ctx, search, limit, offset
+func (_m *MockStore) SearchGames(ctx context.Context, search wording.GameSearch, limit int, offset int) ([]wording.ListedGame, error) {
+	ret := _m.Called(ctx, search, limit, offset)
+
+	var r0 []wording.ListedGame
+	if rf, ok := ret.Get(0).(func(context.Context, wording.GameSearch, int, int) []wording.ListedGame); ok {
+		r0 = rf(ctx, search, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wording.ListedGame)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, wording.GameSearch, int, int) error); ok {
+		r1 = rf(ctx, search, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_SearchGames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchGames'
+type MockStore_SearchGames_Call struct {
+	*mock.Call
+}
+
+// SearchGames is a helper method to define mock.On call
+//  - ctx context.Context
+//  - search wording.GameSearch
+//  - limit int
+//  - offset int
+func (_e *MockStore_Expecter) SearchGames(ctx interface{}, search interface{}, limit interface{}, offset interface{}) *MockStore_SearchGames_Call {
+	return &MockStore_SearchGames_Call{Call: _e.mock.On("SearchGames", ctx, search, limit, offset)}
+}
+
+func (_c *MockStore_SearchGames_Call) Run(run func(ctx context.Context, search wording.GameSearch, limit int, offset int)) *MockStore_SearchGames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(wording.GameSearch), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_SearchGames_Call) Return(_a0 []wording.ListedGame, _a1 error) *MockStore_SearchGames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// SetGameDisabled provides a mock function with given fields: ctx, operator, adminToken, disabled
+func (_m *MockStore) SetGameDisabled(ctx context.Context, operator string, adminToken string, disabled bool) error {
+	ret := _m.Called(ctx, operator, adminToken, disabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, operator, adminToken, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_SetGameDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGameDisabled'
+type MockStore_SetGameDisabled_Call struct {
+	*mock.Call
+}
+
+// SetGameDisabled is a helper method to define mock.On call
+//  - ctx context.Context
+//  - operator string
+//  - adminToken string
+//  - disabled bool
+func (_e *MockStore_Expecter) SetGameDisabled(ctx interface{}, operator interface{}, adminToken interface{}, disabled interface{}) *MockStore_SetGameDisabled_Call {
+	return &MockStore_SetGameDisabled_Call{Call: _e.mock.On("SetGameDisabled", ctx, operator, adminToken, disabled)}
+}
+
+func (_c *MockStore_SetGameDisabled_Call) Run(run func(ctx context.Context, operator string, adminToken string, disabled bool)) *MockStore_SetGameDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStore_SetGameDisabled_Call) Return(_a0 error) *MockStore_SetGameDisabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SetRecoveryEmail provides a mock function with given fields: ctx, adminToken, emailHash
+func (_m *MockStore) SetRecoveryEmail(ctx context.Context, adminToken string, emailHash string) error {
+	ret := _m.Called(ctx, adminToken, emailHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, adminToken, emailHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_SetRecoveryEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRecoveryEmail'
+type MockStore_SetRecoveryEmail_Call struct {
+	*mock.Call
+}
+
+// SetRecoveryEmail is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - emailHash string
+func (_e *MockStore_Expecter) SetRecoveryEmail(ctx interface{}, adminToken interface{}, emailHash interface{}) *MockStore_SetRecoveryEmail_Call {
+	return &MockStore_SetRecoveryEmail_Call{Call: _e.mock.On("SetRecoveryEmail", ctx, adminToken, emailHash)}
+}
+
+func (_c *MockStore_SetRecoveryEmail_Call) Run(run func(ctx context.Context, adminToken string, emailHash string)) *MockStore_SetRecoveryEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_SetRecoveryEmail_Call) Return(_a0 error) *MockStore_SetRecoveryEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SetStats provides a mock function with given fields: ctx, stats
+func (_m *MockStore) SetStats(ctx context.Context, stats wording.Stats) error {
+	ret := _m.Called(ctx, stats)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, wording.Stats) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_SetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStats'
+type MockStore_SetStats_Call struct {
+	*mock.Call
+}
+
+// SetStats is a helper method to define mock.On call
+//  - ctx context.Context
+//  - stats wording.Stats
+func (_e *MockStore_Expecter) SetStats(ctx interface{}, stats interface{}) *MockStore_SetStats_Call {
+	return &MockStore_SetStats_Call{Call: _e.mock.On("SetStats", ctx, stats)}
+}
+
+func (_c *MockStore_SetStats_Call) Run(run func(ctx context.Context, stats wording.Stats)) *MockStore_SetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(wording.Stats))
+	})
+	return _c
+}
+
+func (_c *MockStore_SetStats_Call) Return(_a0 error) *MockStore_SetStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// StartSession provides a mock function with given fields: ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt
+func (_m *MockStore) StartSession(ctx context.Context, loginTokenHash string, playerToken string, newPlayerToken string, sessionHash string, expiresAt time.Time) (*wording.Account, error) {
+	ret := _m.Called(ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)
+
+	var r0 *wording.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, time.Time) *wording.Account); ok {
+		r0 = rf(ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wording.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, time.Time) error); ok {
+		r1 = rf(ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_StartSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSession'
+type MockStore_StartSession_Call struct {
+	*mock.Call
+}
+
+// StartSession is a helper method to define mock.On call
+//  - ctx context.Context
+//  - loginTokenHash string
+//  - playerToken string
+//  - newPlayerToken string
+//  - sessionHash string
+//  - expiresAt time.Time
+func (_e *MockStore_Expecter) StartSession(ctx interface{}, loginTokenHash interface{}, playerToken interface{}, newPlayerToken interface{}, sessionHash interface{}, expiresAt interface{}) *MockStore_StartSession_Call {
+	return &MockStore_StartSession_Call{Call: _e.mock.On("StartSession", ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)}
+}
+
+func (_c *MockStore_StartSession_Call) Run(run func(ctx context.Context, loginTokenHash string, playerToken string, newPlayerToken string, sessionHash string, expiresAt time.Time)) *MockStore_StartSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_StartSession_Call) Return(_a0 *wording.Account, _a1 error) *MockStore_StartSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Stats provides a mock function with given fields: ctx
+func (_m *MockStore) Stats(ctx context.Context) (wording.Stats, error) {
+	ret := _m.Called(ctx)
+
+	var r0 wording.Stats
+	if rf, ok := ret.Get(0).(func(context.Context) wording.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(wording.Stats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockStore_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *MockStore_Expecter) Stats(ctx interface{}) *MockStore_Stats_Call {
+	return &MockStore_Stats_Call{Call: _e.mock.On("Stats", ctx)}
+}
+
+func (_c *MockStore_Stats_Call) Run(run func(ctx context.Context)) *MockStore_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_Stats_Call) Return(_a0 wording.Stats, _a1 error) *MockStore_Stats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// UpdateGameSettings provides a mock function with given fields: ctx, adminToken, settings
+func (_m *MockStore) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	ret := _m.Called(ctx, adminToken, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, wording.GameSettings) error); ok {
+		r0 = rf(ctx, adminToken, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdateGameSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGameSettings'
+type MockStore_UpdateGameSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateGameSettings is a helper method to define mock.On call
+//  - ctx context.Context
+//  - adminToken string
+//  - settings wording.GameSettings
+func (_e *MockStore_Expecter) UpdateGameSettings(ctx interface{}, adminToken interface{}, settings interface{}) *MockStore_UpdateGameSettings_Call {
+	return &MockStore_UpdateGameSettings_Call{Call: _e.mock.On("UpdateGameSettings", ctx, adminToken, settings)}
+}
+
+func (_c *MockStore_UpdateGameSettings_Call) Run(run func(ctx context.Context, adminToken string, settings wording.GameSettings)) *MockStore_UpdateGameSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(wording.GameSettings))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateGameSettings_Call) Return(_a0 error) *MockStore_UpdateGameSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// UseRecoveryToken provides a mock function with given fields: ctx, tokenHash
+func (_m *MockStore) UseRecoveryToken(ctx context.Context, tokenHash string) (string, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UseRecoveryToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryToken'
+type MockStore_UseRecoveryToken_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - tokenHash string
+func (_e *MockStore_Expecter) UseRecoveryToken(ctx interface{}, tokenHash interface{}) *MockStore_UseRecoveryToken_Call {
+	return &MockStore_UseRecoveryToken_Call{Call: _e.mock.On("UseRecoveryToken", ctx, tokenHash)}
+}
+
+func (_c *MockStore_UseRecoveryToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockStore_UseRecoveryToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_UseRecoveryToken_Call) Return(_a0 string, _a1 error) *MockStore_UseRecoveryToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
@@ -2,7 +2,11 @@
 
 package service
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockTokenGenerator is an autogenerated mock type for the TokenGenerator type
 type MockTokenGenerator struct {
@@ -17,13 +21,13 @@ func (_m *MockTokenGenerator) EXPECT() *MockTokenGenerator_Expecter {
 	return &MockTokenGenerator_Expecter{mock: &_m.Mock}
 }
 
-// NewToken provides a mock function with given fields:
-func (_m *MockTokenGenerator) NewToken() string {
-	ret := _m.Called()
+// NewToken provides a mock function with given fields: ctx
+func (_m *MockTokenGenerator) NewToken(ctx context.Context) string {
+	ret := _m.Called(ctx)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -37,13 +41,14 @@ type MockTokenGenerator_NewToken_Call struct {
 }
 
 // NewToken is a helper method to define mock.On call
-func (_e *MockTokenGenerator_Expecter) NewToken() *MockTokenGenerator_NewToken_Call {
-	return &MockTokenGenerator_NewToken_Call{Call: _e.mock.On("NewToken")}
+//  - ctx context.Context
+func (_e *MockTokenGenerator_Expecter) NewToken(ctx interface{}) *MockTokenGenerator_NewToken_Call {
+	return &MockTokenGenerator_NewToken_Call{Call: _e.mock.On("NewToken", ctx)}
 }
 
-func (_c *MockTokenGenerator_NewToken_Call) Run(run func()) *MockTokenGenerator_NewToken_Call {
+func (_c *MockTokenGenerator_NewToken_Call) Run(run func(ctx context.Context)) *MockTokenGenerator_NewToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// RotateAdminToken gives a game a new admin token, for when the old one
+// has leaked, and returns it. The old token stops working straight away.
+func (s *service) RotateAdminToken(ctx context.Context, adminToken string) (string, error) {
+	newAdminToken := s.adminTokenGenerator.NewToken(ctx)
+
+	err := s.store.RotateAdminToken(ctx, adminToken, newAdminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return newAdminToken, nil
+}
+
+// SetRecoveryEmail sets the address that new admin links for a game can
+// be sent to. Only a hash of it is kept. An empty address removes it.
+func (s *service) SetRecoveryEmail(ctx context.Context, adminToken, email string) error {
+	var emailHash string
+
+	email = strings.TrimSpace(email)
+	if email != "" {
+		err := wording.ValidateEmail(email)
+		if err != nil {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+		emailHash = hashEmail(email)
+	}
+
+	err := s.store.SetRecoveryEmail(ctx, adminToken, emailHash)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return err
+}
+
+// RecoveryLinkLifetime is how long a recovery link can be used for.
+const RecoveryLinkLifetime = 15 * time.Minute
+
+// RequestRecovery emails a recovery link to the address if any games can
+// be recovered with it. Nothing is sent if there are none, and that isn't
+// an error, so the response can't be used to find out who created what.
+// No admin links change until the recovery link is used.
+func (s *service) RequestRecovery(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return ErrMailDisabled
+	}
+
+	email = strings.TrimSpace(email)
+	err := wording.ValidateEmail(email)
+	if err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+
+	emailHash := hashEmail(email)
+
+	games, err := s.store.RecoverableGames(ctx, emailHash)
+	if err != nil {
+		return err
+	}
+	if len(games) == 0 {
+		return nil
+	}
+
+	token, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	err = s.store.CreateRecoveryToken(ctx, hashSecret(token), emailHash, time.Now().Add(RecoveryLinkLifetime))
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.SendRecoveryLink(ctx, email, token, RecoveryLinkLifetime)
+}
+
+// RecoverGames uses up a recovery token and gives every game recoverable
+// with its address a new admin token. The games are returned with their
+// new admin tokens, which aren't sent anywhere else. It returns
+// ErrNotFound if the token has expired or was already used.
+func (s *service) RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error) {
+	emailHash, err := s.store.UseRecoveryToken(ctx, hashSecret(recoveryToken))
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.store.RecoverableGames(ctx, emailHash)
+	if err != nil {
+		return nil, err
+	}
+
+	recovered := games[:0]
+	for _, game := range games {
+		game.AdminToken, err = s.RotateAdminToken(ctx, game.AdminToken)
+		if errors.Is(err, ErrNotFound) {
+			// Deleted in the meantime.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		recovered = append(recovered, game)
+	}
+
+	return recovered, nil
+}
@@ -4,64 +4,148 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/logging"
 	"github.com/connorkuehl/wording/internal/store"
 	"github.com/connorkuehl/wording/internal/wording"
 )
 
 //go:generate mockery --name Store --case underscore --with-expecter --testonly --inpackage
 type Store interface {
-	CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int) (*wording.Game, error)
+	CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string) (*wording.Game, error)
 	Game(ctx context.Context, adminToken string) (*wording.Game, error)
 	GameByToken(ctx context.Context, token string) (*wording.Game, error)
 	Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error)
-	PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays) error
+	PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome) error
 	IncrementStats(ctx context.Context, stats wording.IncrementStats) error
-	GameStats(ctx context.Context, adminToken string) (wording.Stats, error)
+	GameStats(ctx context.Context, adminToken string) (wording.GameStats, error)
 	Stats(ctx context.Context) (wording.Stats, error)
 	DeleteGame(ctx context.Context, adminToken string) error
+	PruneGames(ctx context.Context, idleSince time.Time) (int, error)
+	ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error
+	GameRecord(ctx context.Context, adminToken string) (*wording.GameRecord, error)
+	ImportGame(ctx context.Context, record *wording.GameRecord) error
+	SetStats(ctx context.Context, stats wording.Stats) error
+	DailyStats(ctx context.Context, since time.Time) ([]wording.DailyStats, error)
+	GameDailyStats(ctx context.Context, adminToken string, since time.Time) ([]wording.DailyStats, error)
+	PlayerGames(ctx context.Context, playerToken string) ([]wording.PlayerGame, error)
+	DeletePlayerGames(ctx context.Context, playerToken string) (int, error)
+	CreateLoginToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error
+	StartSession(ctx context.Context, loginTokenHash, playerToken, newPlayerToken, sessionHash string, expiresAt time.Time) (*wording.Account, error)
+	AccountSession(ctx context.Context, sessionHash string) (*wording.Account, error)
+	DeleteSession(ctx context.Context, sessionHash string) error
+	CreatedGames(ctx context.Context, creator string, limit, offset int) ([]wording.CreatedGame, error)
+	DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error)
+	RotateAdminToken(ctx context.Context, adminToken, newAdminToken string) error
+	SetRecoveryEmail(ctx context.Context, adminToken, emailHash string) error
+	RecoverableGames(ctx context.Context, emailHash string) ([]wording.Game, error)
+	CreateRecoveryToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error
+	UseRecoveryToken(ctx context.Context, tokenHash string) (string, error)
+	SearchGames(ctx context.Context, search wording.GameSearch, limit, offset int) ([]wording.ListedGame, error)
+	InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error)
+	SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error
+	OperatorDeleteGame(ctx context.Context, operator, adminToken string) error
+	AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error)
+	UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error
 }
 
 //go:generate mockery --name TokenGenerator --case underscore --with-expecter --testonly --inpackage
 type TokenGenerator interface {
-	NewToken() string
+	NewToken(ctx context.Context) string
+}
+
+//go:generate mockery --name DifficultyRater --case underscore --with-expecter --testonly --inpackage
+type DifficultyRater interface {
+	RateDifficulty(answer string) wording.Difficulty
+}
+
+//go:generate mockery --name Mailer --case underscore --with-expecter --testonly --inpackage
+type Mailer interface {
+	SendLoginLink(ctx context.Context, to, token string, expiresIn time.Duration) error
+	SendRecoveryLink(ctx context.Context, to, token string, expiresIn time.Duration) error
 }
 
 type Service interface {
-	CreateGame(ctx context.Context, answer string, guessLimit int) (*wording.Game, error)
+	AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error)
+	ClearPlayerHistory(ctx context.Context, playerToken string) (int, error)
+	CreateGame(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string) (*wording.Game, error)
+	CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error)
+	DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error)
+	DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error)
 	DeleteGame(ctx context.Context, adminToken string) error
+	ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error)
+	ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error
 	Game(ctx context.Context, adminToken string) (*wording.Game, error)
 	GameByToken(ctx context.Context, token string) (*wording.Game, error)
+	GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error)
 	GameState(ctx context.Context, gameToken, playerToken string) (*wording.GameState, error)
-	GameStats(ctx context.Context, adminToken string) (wording.Stats, error)
+	GameStats(ctx context.Context, adminToken string) (wording.GameStats, error)
+	ImportGame(ctx context.Context, record *wording.GameRecord, freshTokens bool) (*wording.GameRecord, error)
+	InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error)
+	Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error)
+	Login(ctx context.Context, loginToken, playerToken string) (*wording.Session, error)
+	Logout(ctx context.Context, sessionToken string) error
+	MailEnabled() bool
 	NewPlayerToken(ctx context.Context) string
+	OperatorDeleteGame(ctx context.Context, operator, adminToken string) error
+	PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error)
+	PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error)
 	Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error)
+	PruneGames(ctx context.Context, idleSince time.Time) (int, error)
+	RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error)
+	RequestLogin(ctx context.Context, email string) error
+	RequestRecovery(ctx context.Context, email string) error
+	RotateAdminToken(ctx context.Context, adminToken string) (string, error)
+	SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error)
+	Session(ctx context.Context, sessionToken string) (*wording.Account, error)
+	SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error
+	SetRecoveryEmail(ctx context.Context, adminToken, email string) error
+	SetStats(ctx context.Context, stats wording.Stats) error
 	Stats(ctx context.Context) (wording.Stats, error)
 	SubmitGuess(ctx context.Context, gameToken, playerToken, guess string) error
+	UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error
 }
 
 type service struct {
 	store               Store
 	adminTokenGenerator TokenGenerator
 	gameTokenGenerator  TokenGenerator
+	rater               DifficultyRater
+	mailer              Mailer
+	log                 logrus.FieldLogger
 }
 
-// New creates a new service.
-func New(store Store, adminTokenGenerator, gameTokenGenerator TokenGenerator) *service {
+// New creates a new service. Players can only log in or recover their
+// games if there is a mailer to send them links.
+func New(
+	store Store,
+	adminTokenGenerator, gameTokenGenerator TokenGenerator,
+	rater DifficultyRater,
+	mailer Mailer,
+	logger logrus.FieldLogger,
+) *service {
 	return &service{
 		store:               store,
 		adminTokenGenerator: adminTokenGenerator,
 		gameTokenGenerator:  gameTokenGenerator,
+		rater:               rater,
+		mailer:              mailer,
+		log:                 logger,
 	}
 }
 
-// CreateGame creates a new guess-the-word game.
+// CreateGame creates a new guess-the-word game. creator is the player
+// token of whoever is creating it, or empty if it isn't a player.
 func (s *service) CreateGame(
 	ctx context.Context,
 	answer string,
 	guessLimit int,
+	settings wording.GameSettings,
+	creator string,
 ) (*wording.Game, error) {
 	err := wording.ValidateAnswer(answer)
 	if err != nil {
@@ -75,15 +159,27 @@ func (s *service) CreateGame(
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
-	game, err := s.store.CreateGame(ctx, s.adminTokenGenerator.NewToken(), s.gameTokenGenerator.NewToken(), answer, guessLimit)
+	settings = settings.WithDefaults()
+	err = wording.ValidateSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	difficulty := s.rater.RateDifficulty(answer)
+
+	game, err := s.store.CreateGame(ctx, s.adminTokenGenerator.NewToken(ctx), s.gameTokenGenerator.NewToken(ctx), answer, guessLimit, difficulty, settings, creator)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.store.IncrementStats(ctx, wording.IncrementStats{Stats: wording.Stats{GamesCreated: 1}})
+	inc := wording.IncrementStats{Stats: wording.Stats{GamesCreated: 1}}
+	err = s.store.IncrementStats(ctx, inc)
 	if err != nil {
-		// TODO
-		log.Println("increment:", err)
+		// The game exists, so don't fail the request over a stats miscount.
+		logging.From(ctx, s.log).WithError(err).WithFields(logrus.Fields{
+			"game":          game.Token,
+			"games_created": inc.GamesCreated,
+		}).Warn("failed to increment stats")
 	}
 
 	return game, nil
@@ -98,27 +194,42 @@ func (s *service) Game(ctx context.Context, adminToken string) (*wording.Game, e
 	return game, err
 }
 
-// GameByToken fetches the game identified by token.
+// GameByToken fetches the game identified by token. It returns
+// ErrGameDisabled if an operator has stopped the game from being played,
+// but games that haven't opened or have closed are returned as usual.
 func (s *service) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
 	game, err := s.store.GameByToken(ctx, token)
 	if errors.Is(err, store.ErrNotFound) {
 		err = ErrNotFound
 	}
-	return game, err
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Disabled {
+		return nil, ErrGameDisabled
+	}
+
+	return game, nil
 }
 
 // SubmitGuess records the player's guess.
 func (s *service) SubmitGuess(ctx context.Context, gameToken, playerToken, guess string) error {
 	guess = strings.ToLower(guess)
 
-	game, err := s.store.GameByToken(ctx, gameToken)
-	if errors.Is(err, store.ErrNotFound) {
-		err = ErrNotFound
-	}
+	game, err := s.GameByToken(ctx, gameToken)
 	if err != nil {
 		return err
 	}
 
+	now := time.Now()
+	if !game.Opened(now) {
+		return ErrGameNotOpen
+	}
+	if game.Closed(now) {
+		return ErrGameClosed
+	}
+
 	plays, err := s.store.Plays(ctx, gameToken, playerToken)
 	if errors.Is(err, store.ErrNotFound) {
 		plays = &wording.Plays{}
@@ -143,26 +254,33 @@ func (s *service) SubmitGuess(ctx context.Context, gameToken, playerToken, guess
 	}
 
 	plays.Attempts = append(plays.Attempts, guess)
-	err = s.store.PutPlays(ctx, gameToken, playerToken, plays)
-	if err != nil {
-		return err
+	state = plays.Evaluate(game.Answer, game.GuessLimit)
+
+	outcome := wording.PlayOutcome{
+		Started: len(plays.Attempts) == 1,
+		Won:     state.IsVictorious,
 	}
 
-	plays, err = s.store.Plays(ctx, gameToken, playerToken)
+	err = s.store.PutPlays(ctx, gameToken, playerToken, plays, outcome)
 	if err != nil {
 		return err
 	}
-	state = plays.Evaluate(game.Answer, game.GuessLimit)
 
 	incWins := 0
 	if state.IsVictorious {
 		incWins = 1
 	}
 
-	err = s.store.IncrementStats(ctx, wording.IncrementStats{Stats: wording.Stats{GuessesMade: 1, GamesWon: incWins}})
+	inc := wording.IncrementStats{Stats: wording.Stats{GuessesMade: 1, GamesWon: incWins}}
+	err = s.store.IncrementStats(ctx, inc)
 	if err != nil {
-		// TODO
-		log.Println("increment:", err)
+		// The guess was recorded, so don't fail the request over a stats
+		// miscount.
+		logging.From(ctx, s.log).WithError(err).WithFields(logrus.Fields{
+			"game":         gameToken,
+			"guesses_made": inc.GuessesMade,
+			"games_won":    inc.GamesWon,
+		}).Warn("failed to increment stats")
 	}
 
 	return nil
@@ -170,22 +288,70 @@ func (s *service) SubmitGuess(ctx context.Context, gameToken, playerToken, guess
 
 // NewPlayerToken allocates a new player token.
 func (s *service) NewPlayerToken(ctx context.Context) string {
-	return s.adminTokenGenerator.NewToken()
+	return s.adminTokenGenerator.NewToken(ctx)
 }
 
 // GameState returns a snapshot of a player's progress against a given game.
+// Nobody can continue a game that hasn't opened or has closed.
 func (s *service) GameState(ctx context.Context, gameToken, playerToken string) (*wording.GameState, error) {
-	game, err := s.store.GameByToken(ctx, gameToken)
-	if errors.Is(err, store.ErrNotFound) {
-		return nil, ErrNotFound
+	game, err := s.GameByToken(ctx, gameToken)
+	if err != nil {
+		return nil, err
 	}
 
 	plays, err := s.store.Plays(ctx, gameToken, playerToken)
 	if errors.Is(err, store.ErrNotFound) {
-		return &wording.GameState{CanContinue: true}, nil
+		plays, err = &wording.Plays{}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return plays.Evaluate(game.Answer, game.GuessLimit), nil
+	state := plays.Evaluate(game.Answer, game.GuessLimit)
+
+	now := time.Now()
+	if !game.Opened(now) || game.Closed(now) {
+		state.CanContinue = false
+	}
+
+	if game.RevealsAnswer(state.IsVictorious, state.GameOver, now) {
+		state.Answer = game.Answer
+	}
+
+	return state, nil
+}
+
+// Leaderboard ranks the players who have won a game.
+func (s *service) Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error) {
+	game, err := s.GameByToken(ctx, gameToken)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.store.GameRecord(ctx, game.AdminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return wording.Leaderboard(record.Answer, record.Players), nil
+}
+
+// UpdateGameSettings replaces a game's settings.
+func (s *service) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	settings = settings.WithDefaults()
+	err := wording.ValidateSettings(settings)
+	if err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+
+	err = s.store.UpdateGameSettings(ctx, adminToken, settings)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return err
 }
 
 // Plays fetches a player's attempts against a game.
@@ -215,7 +381,188 @@ func (s *service) DeleteGame(ctx context.Context, adminToken string) error {
 	return err
 }
 
-// GameStats returns a specific game's stats.
-func (s *service) GameStats(ctx context.Context, adminToken string) (wording.Stats, error) {
-	return s.store.GameStats(ctx, adminToken)
+// GameStats returns a specific game's stats, including how players won
+// and lost.
+func (s *service) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	stats, err := s.store.GameStats(ctx, adminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return stats, err
+}
+
+// PruneGames deletes every game that hasn't been played or managed since
+// idleSince, along with its attempts. It returns the number of games
+// deleted.
+func (s *service) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	return s.store.PruneGames(ctx, idleSince)
+}
+
+// ExportGames calls fn with every game and its plays, oldest first.
+func (s *service) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	return s.store.ExportGames(ctx, fn)
+}
+
+// ExportGame fetches a game and its plays.
+func (s *service) ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	record, err := s.store.GameRecord(ctx, adminToken)
+	if errors.Is(err, store.ErrNotFound) {
+		err = ErrNotFound
+	}
+	return record, err
+}
+
+// ImportGame validates an exported game as if it had been created and
+// played through this service, then stores it. Importing a game that
+// already exists replaces it. If freshTokens is set the game is given new
+// tokens instead, so it can be imported alongside the original. It returns
+// the game as stored.
+func (s *service) ImportGame(ctx context.Context, record *wording.GameRecord, freshTokens bool) (*wording.GameRecord, error) {
+	imported := *record
+	imported.Answer = strings.ToLower(imported.Answer)
+
+	err := wording.ValidateAnswer(imported.Answer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	err = wording.ValidateGuessLimit(imported.GuessLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	imported.GameSettings = imported.GameSettings.WithDefaults()
+	err = wording.ValidateSettings(imported.GameSettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	imported.Players = make([]wording.PlayerRecord, len(record.Players))
+	for i, p := range record.Players {
+		if p.PlayerToken == "" {
+			return nil, fmt.Errorf("invalid input: player %d has no token", i+1)
+		}
+		if len(p.Attempts) > imported.GuessLimit {
+			return nil, fmt.Errorf("invalid input: player %s: %w", p.PlayerToken, ErrGuessLimitReached)
+		}
+
+		attempts := make([]string, 0, len(p.Attempts))
+		for _, guess := range p.Attempts {
+			guess = strings.ToLower(guess)
+
+			err := wording.ValidateGuess(guess, imported.Answer, attempts)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input: player %s: %w", p.PlayerToken, err)
+			}
+
+			state := (&wording.Plays{Attempts: attempts}).Evaluate(imported.Answer, imported.GuessLimit)
+			if !state.CanContinue {
+				return nil, fmt.Errorf("invalid input: player %s: %w", p.PlayerToken, ErrCannotContinue)
+			}
+
+			attempts = append(attempts, guess)
+		}
+
+		p.Attempts = attempts
+		imported.Players[i] = p
+	}
+
+	if imported.Difficulty == nil {
+		difficulty := s.rater.RateDifficulty(imported.Answer)
+		imported.Difficulty = &difficulty
+	}
+
+	if freshTokens || imported.AdminToken == "" || imported.Token == "" {
+		imported.AdminToken = s.adminTokenGenerator.NewToken(ctx)
+		imported.Token = s.gameTokenGenerator.NewToken(ctx)
+	}
+
+	err = s.store.ImportGame(ctx, &imported)
+	if errors.Is(err, store.ErrConflict) {
+		err = ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &imported, nil
+}
+
+// SetStats overwrites the application's lifetime stats, e.g., with the
+// ones exported from another instance.
+func (s *service) SetStats(ctx context.Context, stats wording.Stats) error {
+	return s.store.SetStats(ctx, stats)
+}
+
+// DailyStats returns the stats for every game for each of the last days,
+// including today, oldest first.
+func (s *service) DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error) {
+	from, to := statsWindow(days)
+
+	stats, err := s.store.DailyStats(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return wording.FillDays(stats, from, to), nil
+}
+
+// GameDailyStats returns a game's stats for each of the last days,
+// including today, oldest first.
+func (s *service) GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error) {
+	from, to := statsWindow(days)
+
+	stats, err := s.store.GameDailyStats(ctx, adminToken, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return wording.FillDays(stats, from, to), nil
+}
+
+// statsWindow returns the first and last day of a time series covering
+// the last days, including today.
+func statsWindow(days int) (from, to time.Time) {
+	if days < 1 {
+		days = 1
+	}
+
+	to = wording.Day(time.Now())
+	return to.AddDate(0, 0, 1-days), to
+}
+
+// PlayerHistory returns every game a player has played, oldest first. The
+// answers are left out unless the game's settings let the player see them,
+// so that exporting their history can't spoil anything.
+func (s *service) PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	games, err := s.store.PlayerGames(ctx, playerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range games {
+		g := &games[i]
+		if !g.RevealsAnswer(g.Won(), g.Finished(), now) {
+			g.Answer = ""
+		}
+	}
+
+	return games, nil
+}
+
+// PlayerStats sums up a player's history.
+func (s *service) PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error) {
+	games, err := s.store.PlayerGames(ctx, playerToken)
+	if err != nil {
+		return wording.PlayerStats{}, err
+	}
+
+	return wording.TallyPlayerStats(games, time.Now()), nil
+}
+
+// ClearPlayerHistory forgets every guess a player has made and returns
+// how many games they were for. Games and overall stats aren't affected.
+func (s *service) ClearPlayerHistory(ctx context.Context, playerToken string) (int, error) {
+	return s.store.DeletePlayerGames(ctx, playerToken)
 }
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/mock"
 	"gotest.tools/assert"
 
+	"github.com/connorkuehl/wording/internal/store"
 	"github.com/connorkuehl/wording/internal/wording"
 )
 
@@ -14,29 +19,35 @@ func TestCreateGame(t *testing.T) {
 	tokGen := NewMockTokenGenerator(t)
 	admTokGen := NewMockTokenGenerator(t)
 	mockStore := NewMockStore(t)
+	rater := NewMockDifficultyRater(t)
 
-	admTokGen.EXPECT().NewToken().Return("wretched-apostle")
-	tokGen.EXPECT().NewToken().Return("hungry-hippo")
+	admTokGen.EXPECT().NewToken(mock.Anything).Return("wretched-apostle")
+	tokGen.EXPECT().NewToken(mock.Anything).Return("hungry-hippo")
+	rater.EXPECT().RateDifficulty("answer").Return(wording.Difficulty{Score: 42, SolverGuesses: 4})
 
 	mockStore.EXPECT().
-		CreateGame(mock.Anything, "wretched-apostle", "hungry-hippo", "answer", 3).
+		CreateGame(mock.Anything, "wretched-apostle", "hungry-hippo", "answer", 3, wording.Difficulty{Score: 42, SolverGuesses: 4}, wording.GameSettings{Reveal: wording.RevealOnLoss}, "player-one").
 		Return(&wording.Game{
 			AdminToken: "wretched-apostle",
 			Token:      "hungry-hippo",
 			Answer:     "answer",
 			GuessLimit: 3,
+			Difficulty: &wording.Difficulty{Score: 42, SolverGuesses: 4},
 		}, nil).
 		Once()
 	mockStore.EXPECT().
 		IncrementStats(mock.Anything, wording.IncrementStats{Stats: wording.Stats{GamesCreated: 1}}).
 		Return(nil)
 
-	svc := New(mockStore, admTokGen, tokGen)
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, admTokGen, tokGen, rater, nil, logger)
 
 	got, err := svc.CreateGame(
 		context.TODO(),
 		"answer",
 		3,
+		wording.GameSettings{},
+		"player-one",
 	)
 	assert.NilError(t, err)
 
@@ -45,7 +56,491 @@ func TestCreateGame(t *testing.T) {
 		Token:      "hungry-hippo",
 		Answer:     "answer",
 		GuessLimit: 3,
+		Difficulty: &wording.Difficulty{Score: 42, SolverGuesses: 4},
 	}
 
 	assert.DeepEqual(t, want, got)
 }
+
+func TestCreateGameRejectsEmptyAnswer(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	svc := New(NewMockStore(t), NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+	_, err := svc.CreateGame(context.TODO(), "", 3, wording.GameSettings{}, "player-one")
+
+	var violations wording.InputViolations
+	assert.Assert(t, errors.As(err, &violations), err)
+}
+
+func TestCreateGameWarnsWhenStatsFail(t *testing.T) {
+	tokGen := NewMockTokenGenerator(t)
+	admTokGen := NewMockTokenGenerator(t)
+	mockStore := NewMockStore(t)
+	rater := NewMockDifficultyRater(t)
+
+	admTokGen.EXPECT().NewToken(mock.Anything).Return("wretched-apostle")
+	tokGen.EXPECT().NewToken(mock.Anything).Return("hungry-hippo")
+	rater.EXPECT().RateDifficulty("answer").Return(wording.Difficulty{})
+
+	mockStore.EXPECT().
+		CreateGame(mock.Anything, "wretched-apostle", "hungry-hippo", "answer", 3, wording.Difficulty{}, wording.GameSettings{Reveal: wording.RevealOnLoss}, "").
+		Return(&wording.Game{AdminToken: "wretched-apostle", Token: "hungry-hippo", Answer: "answer", GuessLimit: 3}, nil).
+		Once()
+	mockStore.EXPECT().
+		IncrementStats(mock.Anything, mock.Anything).
+		Return(errors.New("connection reset"))
+
+	logger, hook := test.NewNullLogger()
+	svc := New(mockStore, admTokGen, tokGen, rater, nil, logger)
+
+	_, err := svc.CreateGame(context.TODO(), "answer", 3, wording.GameSettings{}, "")
+	assert.NilError(t, err)
+
+	entry := hook.LastEntry()
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "hungry-hippo", entry.Data["game"])
+	assert.Equal(t, 1, entry.Data["games_created"])
+}
+
+func TestImportGame(t *testing.T) {
+	record := &wording.GameRecord{
+		Game: wording.Game{
+			AdminToken: "wretched-apostle",
+			Token:      "hungry-hippo",
+			Answer:     "Potato",
+			GuessLimit: 3,
+			Disabled:   true,
+		},
+		Players: []wording.PlayerRecord{
+			{PlayerToken: "alice", Plays: wording.Plays{Attempts: []string{"TOMATO", "potato"}}},
+		},
+	}
+
+	t.Run("keeps tokens", func(t *testing.T) {
+		mockStore := NewMockStore(t)
+		rater := NewMockDifficultyRater(t)
+
+		rater.EXPECT().RateDifficulty("potato").Return(wording.Difficulty{Score: 30})
+		mockStore.EXPECT().
+			ImportGame(mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		logger, _ := test.NewNullLogger()
+		svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), rater, nil, logger)
+
+		got, err := svc.ImportGame(context.TODO(), record, false)
+		assert.NilError(t, err)
+
+		assert.Equal(t, "wretched-apostle", got.AdminToken)
+		assert.Equal(t, "potato", got.Answer)
+		assert.Assert(t, got.Disabled)
+		assert.Equal(t, wording.RevealOnLoss, got.Reveal, "exports from before reveal was added reveal on loss")
+		assert.DeepEqual(t, &wording.Difficulty{Score: 30}, got.Difficulty)
+		assert.DeepEqual(t, []string{"tomato", "potato"}, got.Players[0].Attempts)
+		assert.Equal(t, "TOMATO", record.Players[0].Attempts[0], "the caller's record must not be modified")
+	})
+
+	t.Run("fresh tokens", func(t *testing.T) {
+		mockStore := NewMockStore(t)
+		tokGen := NewMockTokenGenerator(t)
+		admTokGen := NewMockTokenGenerator(t)
+		rater := NewMockDifficultyRater(t)
+
+		admTokGen.EXPECT().NewToken(mock.Anything).Return("calm-walrus")
+		tokGen.EXPECT().NewToken(mock.Anything).Return("sleepy-sloth")
+		rater.EXPECT().RateDifficulty("potato").Return(wording.Difficulty{})
+		mockStore.EXPECT().
+			ImportGame(mock.Anything, mock.MatchedBy(func(r *wording.GameRecord) bool {
+				return r.AdminToken == "calm-walrus" && r.Token == "sleepy-sloth"
+			})).
+			Return(nil).
+			Once()
+
+		logger, _ := test.NewNullLogger()
+		svc := New(mockStore, admTokGen, tokGen, rater, nil, logger)
+
+		_, err := svc.ImportGame(context.TODO(), record, true)
+		assert.NilError(t, err)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		mockStore := NewMockStore(t)
+		rater := NewMockDifficultyRater(t)
+
+		rater.EXPECT().RateDifficulty("potato").Return(wording.Difficulty{})
+		mockStore.EXPECT().
+			ImportGame(mock.Anything, mock.Anything).
+			Return(store.ErrConflict).
+			Once()
+
+		logger, _ := test.NewNullLogger()
+		svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), rater, nil, logger)
+
+		_, err := svc.ImportGame(context.TODO(), record, false)
+		assert.Assert(t, errors.Is(err, ErrConflict), err)
+	})
+}
+
+func TestImportGameRejectsInvalidPlays(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts []string
+		want     string
+	}{
+		{name: "wrong length", attempts: []string{"cat"}, want: "guess must be 6 characters long"},
+		{name: "repeated", attempts: []string{"tomato", "tomato"}, want: "has already been tried"},
+		{name: "over the limit", attempts: []string{"tomato", "banana", "orange", "carrot"}, want: ErrGuessLimitReached.Error()},
+		{name: "after winning", attempts: []string{"potato", "tomato"}, want: ErrCannotContinue.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := test.NewNullLogger()
+			svc := New(NewMockStore(t), NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+			_, err := svc.ImportGame(context.TODO(), &wording.GameRecord{
+				Game: wording.Game{AdminToken: "a", Token: "t", Answer: "potato", GuessLimit: 3},
+				Players: []wording.PlayerRecord{
+					{PlayerToken: "alice", Plays: wording.Plays{Attempts: tt.attempts}},
+				},
+			}, false)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSubmitGuessRecordsOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous []string
+		guess    string
+		want     wording.PlayOutcome
+	}{
+		{name: "first guess", guess: "tomato", want: wording.PlayOutcome{Started: true}},
+		{name: "later guess", previous: []string{"tomato"}, guess: "banana", want: wording.PlayOutcome{}},
+		{name: "win", previous: []string{"tomato"}, guess: "potato", want: wording.PlayOutcome{Won: true}},
+		{name: "win first time", guess: "potato", want: wording.PlayOutcome{Started: true, Won: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := NewMockStore(t)
+
+			mockStore.EXPECT().
+				GameByToken(mock.Anything, "hungry-hippo").
+				Return(&wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 6}, nil)
+			if tt.previous == nil {
+				mockStore.EXPECT().Plays(mock.Anything, "hungry-hippo", "player").Return(nil, store.ErrNotFound)
+			} else {
+				mockStore.EXPECT().
+					Plays(mock.Anything, "hungry-hippo", "player").
+					Return(&wording.Plays{Attempts: tt.previous}, nil)
+			}
+			mockStore.EXPECT().
+				PutPlays(mock.Anything, "hungry-hippo", "player", mock.Anything, tt.want).
+				Return(nil).
+				Once()
+			mockStore.EXPECT().IncrementStats(mock.Anything, mock.Anything).Return(nil)
+
+			logger, _ := test.NewNullLogger()
+			svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+			err := svc.SubmitGuess(context.TODO(), "hungry-hippo", "player", tt.guess)
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestPlayerHistoryHidesUnfinishedAnswers(t *testing.T) {
+	onLoss := wording.GameSettings{Reveal: wording.RevealOnLoss}
+	never := wording.GameSettings{Reveal: wording.RevealNever}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().
+		PlayerGames(mock.Anything, "player").
+		Return([]wording.PlayerGame{
+			{Token: "won", Answer: "potato", GuessLimit: 3, Plays: wording.Plays{Attempts: []string{"potato"}}, GameSettings: onLoss},
+			{Token: "lost", Answer: "potato", GuessLimit: 1, Plays: wording.Plays{Attempts: []string{"tomato"}}, GameSettings: onLoss},
+			{Token: "playing", Answer: "potato", GuessLimit: 3, Plays: wording.Plays{Attempts: []string{"tomato"}}, GameSettings: onLoss},
+			{Token: "secret", Answer: "potato", GuessLimit: 1, Plays: wording.Plays{Attempts: []string{"tomato"}}, GameSettings: never},
+			{Token: "secret-won", Answer: "potato", GuessLimit: 1, Plays: wording.Plays{Attempts: []string{"potato"}}, GameSettings: never},
+		}, nil)
+
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+	games, err := svc.PlayerHistory(context.TODO(), "player")
+	assert.NilError(t, err)
+
+	assert.Equal(t, "potato", games[0].Answer)
+	assert.Equal(t, "potato", games[1].Answer)
+	assert.Equal(t, "", games[2].Answer)
+	assert.Equal(t, "", games[3].Answer)
+	assert.Equal(t, "potato", games[4].Answer)
+}
+
+func TestRequestLogin(t *testing.T) {
+	mockStore := NewMockStore(t)
+	mailer := NewMockMailer(t)
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), mailer, logger)
+
+	var storedHash string
+	mockStore.EXPECT().
+		CreateLoginToken(mock.Anything, mock.Anything, hashEmail("player@example.com"), mock.Anything).
+		Run(func(_ context.Context, tokenHash, _ string, _ time.Time) { storedHash = tokenHash }).
+		Return(nil).
+		Once()
+
+	var sentToken string
+	mailer.EXPECT().
+		SendLoginLink(mock.Anything, "Player@example.com", mock.Anything, LoginLinkLifetime).
+		Run(func(_ context.Context, _, token string, _ time.Duration) { sentToken = token }).
+		Return(nil).
+		Once()
+
+	err := svc.RequestLogin(context.Background(), " Player@example.com ")
+	assert.NilError(t, err)
+
+	// Only the player gets the token itself.
+	assert.Assert(t, sentToken != "")
+	assert.Equal(t, hashSecret(sentToken), storedHash)
+}
+
+func TestRequestLoginRejects(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	disabled := New(NewMockStore(t), NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+	err := disabled.RequestLogin(context.Background(), "player@example.com")
+	assert.Equal(t, ErrMailDisabled, err)
+
+	svc := New(NewMockStore(t), NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), NewMockMailer(t), logger)
+	err = svc.RequestLogin(context.Background(), "Player <player@example.com>")
+	var violations wording.InputViolations
+	assert.Assert(t, errors.As(err, &violations), err)
+}
+
+func TestLoginExpired(t *testing.T) {
+	mockStore := NewMockStore(t)
+	logger, _ := test.NewNullLogger()
+	adminGen := NewMockTokenGenerator(t)
+	svc := New(mockStore, adminGen, NewMockTokenGenerator(t), NewMockDifficultyRater(t), NewMockMailer(t), logger)
+
+	adminGen.EXPECT().NewToken(mock.Anything).Return("fresh-player").Once()
+	mockStore.EXPECT().
+		StartSession(mock.Anything, hashSecret("used"), "player-one", "fresh-player", mock.Anything, mock.Anything).
+		Return(nil, store.ErrNotFound).
+		Once()
+
+	_, err := svc.Login(context.Background(), "used", "player-one")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLoginGivesNewAccountsTheirOwnPlayer(t *testing.T) {
+	mockStore := NewMockStore(t)
+	logger, _ := test.NewNullLogger()
+	adminGen := NewMockTokenGenerator(t)
+	svc := New(mockStore, adminGen, NewMockTokenGenerator(t), NewMockDifficultyRater(t), NewMockMailer(t), logger)
+
+	adminGen.EXPECT().NewToken(mock.Anything).Return("account-player").Once()
+	mockStore.EXPECT().
+		StartSession(mock.Anything, hashSecret("s3cret"), "browser-player", "account-player", mock.Anything, mock.Anything).
+		Return(&wording.Account{ID: 1, PlayerToken: "account-player"}, nil).
+		Once()
+
+	session, err := svc.Login(context.Background(), "s3cret", "browser-player")
+	assert.NilError(t, err)
+	assert.Equal(t, "account-player", session.Account.PlayerToken)
+}
+
+func TestCreatedGamesPages(t *testing.T) {
+	mockStore := NewMockStore(t)
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+	full := make([]wording.CreatedGame, CreatedGamesPerPage+1)
+	mockStore.EXPECT().
+		CreatedGames(mock.Anything, "player-one", CreatedGamesPerPage+1, CreatedGamesPerPage).
+		Return(full, nil).
+		Once()
+	mockStore.EXPECT().
+		CreatedGames(mock.Anything, "player-one", CreatedGamesPerPage+1, 2*CreatedGamesPerPage).
+		Return(full[:3], nil).
+		Once()
+
+	got, err := svc.CreatedGames(context.Background(), "player-one", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, CreatedGamesPerPage, len(got.Games))
+	assert.Equal(t, 2, got.Page)
+	assert.Assert(t, got.More)
+
+	got, err = svc.CreatedGames(context.Background(), "player-one", 3)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(got.Games))
+	assert.Assert(t, !got.More)
+}
+
+func TestRequestRecovery(t *testing.T) {
+	mockStore := NewMockStore(t)
+	mailer := NewMockMailer(t)
+	logger, _ := test.NewNullLogger()
+	// Asking doesn't replace any admin links, so no tokens are generated.
+	svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), mailer, logger)
+
+	mockStore.EXPECT().
+		RecoverableGames(mock.Anything, hashEmail("creator@example.com")).
+		Return([]wording.Game{{AdminToken: "leaked", Token: "hungry-hippo"}}, nil).
+		Once()
+	mockStore.EXPECT().
+		RecoverableGames(mock.Anything, hashEmail("nobody@example.com")).
+		Return(nil, nil).
+		Once()
+
+	var storedHash string
+	mockStore.EXPECT().
+		CreateRecoveryToken(mock.Anything, mock.Anything, hashEmail("creator@example.com"), mock.Anything).
+		Run(func(_ context.Context, tokenHash, _ string, _ time.Time) { storedHash = tokenHash }).
+		Return(nil).
+		Once()
+
+	var mailedToken string
+	mailer.EXPECT().
+		SendRecoveryLink(mock.Anything, "Creator@example.com", mock.Anything, RecoveryLinkLifetime).
+		Run(func(_ context.Context, _, token string, _ time.Duration) { mailedToken = token }).
+		Return(nil).
+		Once()
+
+	err := svc.RequestRecovery(context.Background(), "Creator@example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, hashSecret(mailedToken), storedHash, "only the token's hash may be stored")
+
+	// No mail, and no error, for an address without games.
+	err = svc.RequestRecovery(context.Background(), "nobody@example.com")
+	assert.NilError(t, err)
+}
+
+func TestRecoverGames(t *testing.T) {
+	mockStore := NewMockStore(t)
+	adminGen := NewMockTokenGenerator(t)
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, adminGen, NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+	mockStore.EXPECT().
+		UseRecoveryToken(mock.Anything, hashSecret("s3cret")).
+		Return(hashEmail("creator@example.com"), nil).
+		Once()
+	mockStore.EXPECT().
+		UseRecoveryToken(mock.Anything, hashSecret("s3cret")).
+		Return("", store.ErrNotFound).
+		Once()
+	mockStore.EXPECT().
+		RecoverableGames(mock.Anything, hashEmail("creator@example.com")).
+		Return([]wording.Game{
+			{AdminToken: "leaked", Token: "hungry-hippo"},
+			{AdminToken: "deleted", Token: "sleepy-sloth"},
+		}, nil).
+		Once()
+
+	adminGen.EXPECT().NewToken(mock.Anything).Return("fresh").Once()
+	adminGen.EXPECT().NewToken(mock.Anything).Return("unused").Once()
+	mockStore.EXPECT().RotateAdminToken(mock.Anything, "leaked", "fresh").Return(nil).Once()
+	mockStore.EXPECT().RotateAdminToken(mock.Anything, "deleted", "unused").Return(store.ErrNotFound).Once()
+
+	games, err := svc.RecoverGames(context.Background(), "s3cret")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []wording.Game{{AdminToken: "fresh", Token: "hungry-hippo"}}, games)
+
+	// The link only works once.
+	_, err = svc.RecoverGames(context.Background(), "s3cret")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestDisabledGamesCantBePlayed(t *testing.T) {
+	mockStore := NewMockStore(t)
+	logger, _ := test.NewNullLogger()
+	svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+	mockStore.EXPECT().
+		GameByToken(mock.Anything, "hungry-hippo").
+		Return(&wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 3, Disabled: true}, nil)
+
+	err := svc.SubmitGuess(context.Background(), "hungry-hippo", "player-one", "tomato")
+	assert.Equal(t, ErrGameDisabled, err)
+
+	_, err = svc.GameState(context.Background(), "hungry-hippo", "player-one")
+	assert.Equal(t, ErrGameDisabled, err)
+}
+
+func TestScheduledGamesOnlyTakeGuessesWhileOpen(t *testing.T) {
+	hour := time.Hour
+	tests := []struct {
+		name     string
+		settings wording.GameSettings
+		want     error
+	}{
+		{"not open yet", wording.GameSettings{OpensAt: time.Now().Add(hour)}, ErrGameNotOpen},
+		{"closed", wording.GameSettings{OpensAt: time.Now().Add(-2 * hour), ClosesAt: time.Now().Add(-hour)}, ErrGameClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := NewMockStore(t)
+			logger, _ := test.NewNullLogger()
+			svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+			mockStore.EXPECT().
+				GameByToken(mock.Anything, "hungry-hippo").
+				Return(&wording.Game{Token: "hungry-hippo", Answer: "potato", GuessLimit: 3, GameSettings: tt.settings}, nil)
+			mockStore.EXPECT().
+				Plays(mock.Anything, "hungry-hippo", "player-one").
+				Return(&wording.Plays{}, nil)
+
+			err := svc.SubmitGuess(context.Background(), "hungry-hippo", "player-one", "tomato")
+			assert.Equal(t, tt.want, err)
+
+			state, err := svc.GameState(context.Background(), "hungry-hippo", "player-one")
+			assert.NilError(t, err)
+			assert.Assert(t, !state.CanContinue)
+		})
+	}
+}
+
+func TestGameStateRevealsAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		reveal   wording.Reveal
+		attempts []string
+		want     string
+	}{
+		{"playing", wording.RevealOnLoss, []string{"tomato"}, ""},
+		{"lost", wording.RevealOnLoss, []string{"tomato", "tomcat"}, "potato"},
+		{"lost before closing", wording.RevealOnClose, []string{"tomato", "tomcat"}, ""},
+		{"lost and never revealed", wording.RevealNever, []string{"tomato", "tomcat"}, ""},
+		{"won", wording.RevealNever, []string{"potato"}, "potato"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := NewMockStore(t)
+			logger, _ := test.NewNullLogger()
+			svc := New(mockStore, NewMockTokenGenerator(t), NewMockTokenGenerator(t), NewMockDifficultyRater(t), nil, logger)
+
+			mockStore.EXPECT().
+				GameByToken(mock.Anything, "hungry-hippo").
+				Return(&wording.Game{
+					Token:        "hungry-hippo",
+					Answer:       "potato",
+					GuessLimit:   2,
+					GameSettings: wording.GameSettings{Reveal: tt.reveal},
+				}, nil)
+			mockStore.EXPECT().
+				Plays(mock.Anything, "hungry-hippo", "player-one").
+				Return(&wording.Plays{Attempts: tt.attempts}, nil)
+
+			state, err := svc.GameState(context.Background(), "hungry-hippo", "player-one")
+			assert.NilError(t, err)
+			assert.Equal(t, tt.want, state.Answer)
+		})
+	}
+}
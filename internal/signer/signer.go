@@ -0,0 +1,82 @@
+// Package signer authenticates values handed to clients, such as cookies,
+// so that tampering can be detected when they are sent back.
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+const separator = "."
+
+var (
+	// ErrInvalidSignature means the value was not signed by any of the
+	// signer's keys.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnsigned means the value does not carry a signature at all.
+	ErrUnsigned = errors.New("value is not signed")
+	// ErrNoKeys means a signer was requested without any keys.
+	ErrNoKeys = errors.New("at least one key is required")
+)
+
+// Signer signs values with HMAC-SHA256.
+//
+// The first key is used for signing new values and every key is accepted
+// when verifying, which allows keys to be rotated: add the new key to the
+// front of the list, and drop the old key once every value signed with it
+// has expired.
+type Signer struct {
+	keys [][]byte
+}
+
+// New creates a new Signer. The first key is the active signing key.
+func New(keys ...[]byte) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	for _, key := range keys {
+		if len(key) == 0 {
+			return nil, errors.New("keys must not be empty")
+		}
+	}
+
+	return &Signer{keys: keys}, nil
+}
+
+// Sign returns the value with its signature appended.
+func (s *Signer) Sign(value string) string {
+	return value + separator + base64.RawURLEncoding.EncodeToString(mac(s.keys[0], value))
+}
+
+// Verify checks the signature on a signed value and returns the original
+// value if any of the keys produced it.
+func (s *Signer) Verify(signed string) (string, error) {
+	i := strings.LastIndex(signed, separator)
+	if i < 0 {
+		return "", ErrUnsigned
+	}
+
+	value, encoded := signed[:i], signed[i+len(separator):]
+	sig, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	for _, key := range s.keys {
+		if hmac.Equal(sig, mac(key, value)) {
+			return value, nil
+		}
+	}
+
+	return "", ErrInvalidSignature
+}
+
+func mac(key []byte, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
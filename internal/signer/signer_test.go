@@ -0,0 +1,60 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	s, err := New([]byte("secret"))
+	assert.NilError(t, err)
+
+	got, err := s.Verify(s.Sign("hungry-hippo"))
+	assert.NilError(t, err)
+	assert.Equal(t, "hungry-hippo", got)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	s, err := New([]byte("secret"))
+	assert.NilError(t, err)
+
+	other, err := New([]byte("not-the-secret"))
+	assert.NilError(t, err)
+
+	signed := s.Sign("hungry-hippo")
+
+	tests := []struct {
+		name  string
+		value string
+		want  error
+	}{
+		{name: "unsigned", value: "hungry-hippo", want: ErrUnsigned},
+		{name: "tampered value", value: "wretched-apostle" + signed[len("hungry-hippo"):], want: ErrInvalidSignature},
+		{name: "garbage signature", value: "hungry-hippo.!!!", want: ErrInvalidSignature},
+		{name: "other key", value: other.Sign("hungry-hippo"), want: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Verify(tt.value)
+			assert.Assert(t, errors.Is(err, tt.want), err)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	old, err := New([]byte("old"))
+	assert.NilError(t, err)
+
+	rotated, err := New([]byte("new"), []byte("old"))
+	assert.NilError(t, err)
+
+	got, err := rotated.Verify(old.Sign("hungry-hippo"))
+	assert.NilError(t, err)
+	assert.Equal(t, "hungry-hippo", got)
+
+	_, err = old.Verify(rotated.Sign("hungry-hippo"))
+	assert.ErrorContains(t, err, "invalid signature")
+}
@@ -0,0 +1,4 @@
+// Package solver finds the words that are still possible given a player's
+// attempts and suggests the guess that is expected to narrow them down the
+// most.
+package solver
@@ -0,0 +1,28 @@
+package solver
+
+import "github.com/connorkuehl/wording/internal/wording"
+
+// Rater rates answers against a word list.
+type Rater struct {
+	solver *Solver
+}
+
+// NewRater creates a rater. Without a solver, answers are rated by their
+// letters alone.
+func NewRater(s *Solver) *Rater {
+	return &Rater{solver: s}
+}
+
+// RateDifficulty rates how hard answer is to guess.
+func (r *Rater) RateDifficulty(answer string) wording.Difficulty {
+	if r.solver == nil {
+		return wording.RateDifficulty(answer, wording.DifficultyFactors{})
+	}
+
+	return wording.RateDifficulty(answer, wording.DifficultyFactors{
+		HaveWordList:  true,
+		InWordList:    r.solver.Known(answer),
+		Rarity:        r.solver.Rarity(answer),
+		SolverGuesses: len(r.solver.Solve(answer, 0)),
+	})
+}
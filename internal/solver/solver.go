@@ -0,0 +1,523 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// MaxLength is the longest word the solver considers, because a guess's
+// feedback is packed into a uint64.
+const MaxLength = 40
+
+// ReadWords reads a word list with one word per line, optionally followed
+// by how often the word is used. Lines starting with # are ignored. counts
+// is empty if the list has no counts.
+func ReadWords(r io.Reader) (words []string, counts map[string]int, err error) {
+	counts = make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		words = append(words, fields[0])
+
+		if len(fields) > 1 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, nil, fmt.Errorf("count for %q: %w", fields[0], err)
+			}
+			counts[strings.ToLower(fields[0])] += n
+		}
+	}
+
+	return words, counts, scanner.Err()
+}
+
+// Solver ranks guesses against a word list.
+type Solver struct {
+	byLength map[int][]string
+	// rank is each word's position among words of the same length, most
+	// used first, if the list had counts.
+	rank map[string]int
+
+	// openers caches the best first guess for each length, since it only
+	// depends on the word list and is the slowest to find.
+	mu      sync.Mutex
+	openers map[int]string
+}
+
+// New creates a solver for words. Words are lowercased, and ones that
+// could never be an answer, like those with punctuation, are dropped.
+// counts, if not empty, is how often each word is used.
+func New(words []string, counts map[string]int) *Solver {
+	s := &Solver{
+		byLength: make(map[int][]string),
+		openers:  make(map[int]string),
+	}
+
+	seen := make(map[string]bool, len(words))
+	for _, w := range words {
+		w = strings.ToLower(w)
+		if seen[w] || len(w) == 0 || len(w) > MaxLength || !isLower(w) {
+			continue
+		}
+		seen[w] = true
+		s.byLength[len(w)] = append(s.byLength[len(w)], w)
+	}
+
+	if len(counts) > 0 {
+		s.rank = make(map[string]int, len(seen))
+	}
+
+	for _, ws := range s.byLength {
+		if s.rank != nil {
+			byUse := append([]string(nil), ws...)
+			sort.SliceStable(byUse, func(i, j int) bool {
+				return counts[byUse[i]] > counts[byUse[j]]
+			})
+			for i, w := range byUse {
+				s.rank[w] = i
+			}
+		}
+
+		sort.Strings(ws)
+	}
+
+	return s
+}
+
+// Known reports whether word is in the word list.
+func (s *Solver) Known(word string) bool {
+	word = strings.ToLower(word)
+	return contains(s.byLength[len(word)], word)
+}
+
+// Rarity is how uncommon word is among known words of the same length,
+// from 0 for the most used to 1 for the least. Without counts every known
+// word is middling, and unknown words are the rarest.
+func (s *Solver) Rarity(word string) float64 {
+	word = strings.ToLower(word)
+
+	if !s.Known(word) {
+		return 1
+	}
+
+	rank, ok := s.rank[word]
+	if !ok {
+		return 0.5
+	}
+
+	n := len(s.byLength[len(word)])
+	if n < 2 {
+		return 0
+	}
+
+	return float64(rank) / float64(n-1)
+}
+
+// Words returns the known words of the given length.
+func (s *Solver) Words(length int) []string {
+	return s.byLength[length]
+}
+
+// Candidates returns the known words of the given length that are
+// consistent with every attempt in state.
+func (s *Solver) Candidates(length int, state *wording.GameState) []string {
+	return Filter(s.Words(length), state)
+}
+
+// Filter returns the words consistent with every attempt in state.
+func Filter(words []string, state *wording.GameState) []string {
+	type constraint struct {
+		guess    string
+		feedback uint64
+	}
+
+	var constraints []constraint
+	for _, attempt := range state.Attempts {
+		constraints = append(constraints, constraint{
+			guess:    strings.ToLower(attempt.String()),
+			feedback: feedbackOf(attempt),
+		})
+	}
+
+	var out []string
+	for _, w := range words {
+		ok := true
+		for _, c := range constraints {
+			if len(w) != len(c.guess) || feedback(w, c.guess) != c.feedback {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, w)
+		}
+	}
+
+	return out
+}
+
+// Guess is a suggested next guess.
+type Guess struct {
+	Word string
+	// Bits is the expected information the guess reveals about the answer.
+	Bits float64
+	// Remaining is the expected number of candidates left afterwards.
+	Remaining float64
+	// Candidate reports whether the guess could itself be the answer.
+	Candidate bool
+}
+
+// Rank scores every word in pool by how well it splits candidates, and
+// returns the best n (or all of them if n <= 0). Ties go to words that
+// could be the answer.
+func Rank(candidates, pool []string, n int) []Guess {
+	isCandidate := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		isCandidate[c] = true
+	}
+
+	total := float64(len(candidates))
+	counts := newCounter(len(candidates), pool)
+
+	masks := make([]uint32, len(candidates))
+	for i, c := range candidates {
+		masks[i] = letters(c)
+	}
+
+	guesses := make([]Guess, 0, len(pool))
+	for _, g := range pool {
+		counts.reset()
+		for i, c := range candidates {
+			counts.add(maskedFeedback(c, masks[i], g))
+		}
+
+		var bits, remaining float64
+		counts.each(func(n int) {
+			p := float64(n) / total
+			bits -= p * math.Log2(p)
+			remaining += p * float64(n)
+		})
+
+		guesses = append(guesses, Guess{
+			Word:      g,
+			Bits:      bits,
+			Remaining: remaining,
+			Candidate: isCandidate[g],
+		})
+	}
+
+	sort.SliceStable(guesses, func(i, j int) bool {
+		a, b := guesses[i], guesses[j]
+		if a.Bits != b.Bits {
+			return a.Bits > b.Bits
+		}
+		if a.Candidate != b.Candidate {
+			return a.Candidate
+		}
+		return a.Word < b.Word
+	})
+
+	if n > 0 && n < len(guesses) {
+		guesses = guesses[:n]
+	}
+
+	return guesses
+}
+
+// counter tallies how many candidates give each feedback. Words of up to
+// ten letters have few enough possible feedbacks (3^10) to count in a
+// slice, which is much faster than a map.
+type counter struct {
+	slots   []int
+	touched []uint64
+	m       map[uint64]int
+}
+
+func newCounter(candidates int, pool []string) *counter {
+	if len(pool) == 0 || len(pool[0]) > 10 {
+		return &counter{m: make(map[uint64]int)}
+	}
+
+	n := 1
+	for range pool[0] {
+		n *= 3
+	}
+	return &counter{slots: make([]int, n), touched: make([]uint64, 0, candidates)}
+}
+
+func (c *counter) reset() {
+	if c.m != nil {
+		for k := range c.m {
+			delete(c.m, k)
+		}
+		return
+	}
+	for _, f := range c.touched {
+		c.slots[f] = 0
+	}
+	c.touched = c.touched[:0]
+}
+
+func (c *counter) add(f uint64) {
+	if c.m != nil {
+		c.m[f]++
+		return
+	}
+	if c.slots[f] == 0 {
+		c.touched = append(c.touched, f)
+	}
+	c.slots[f]++
+}
+
+func (c *counter) each(fn func(n int)) {
+	if c.m != nil {
+		for _, n := range c.m {
+			fn(n)
+		}
+		return
+	}
+	for _, f := range c.touched {
+		fn(c.slots[f])
+	}
+}
+
+// Hint suggests the best next guess for a player, or "" if no known word
+// fits their attempts. Only candidates are considered so that the hint can
+// always win.
+func (s *Solver) Hint(length int, state *wording.GameState) string {
+	candidates := s.Candidates(length, state)
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	return Rank(candidates, candidates, 1)[0].Word
+}
+
+// maxPool bounds how many guesses Solve ranks at each step. Ranking is
+// quadratic in the number of words, so with a big word list only the most
+// promising guesses are scored.
+const maxPool = 200
+
+// Solve plays answer by always taking the highest ranked candidate, and
+// returns the guesses it made, ending with the answer. It gives up after
+// limit guesses if limit is positive. The answer doesn't have to be in
+// the word list.
+func (s *Solver) Solve(answer string, limit int) []string {
+	answer = strings.ToLower(answer)
+
+	candidates := s.Words(len(answer))
+	if !contains(candidates, answer) {
+		candidates = append(append([]string(nil), candidates...), answer)
+	}
+
+	var guesses []string
+	for limit <= 0 || len(guesses) < limit {
+		var guess string
+		if len(guesses) == 0 {
+			guess = s.opener(candidates)
+		} else {
+			guess = Rank(candidates, shortlist(candidates, maxPool), 1)[0].Word
+		}
+		guesses = append(guesses, guess)
+		if guess == answer {
+			break
+		}
+
+		state := &wording.GameState{Attempts: []wording.Attempt{wording.Evaluate(answer, guess)}}
+		candidates = Filter(candidates, state)
+	}
+
+	return guesses
+}
+
+// opener returns the best first guess among candidates, which are all of
+// the known words of one length, plus perhaps an unknown answer. The
+// unknown answer is left out so that the opener can be shared.
+func (s *Solver) opener(candidates []string) string {
+	length := len(candidates[0])
+	words := s.Words(length)
+	if len(words) == 0 {
+		return candidates[0]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	guess, ok := s.openers[length]
+	if !ok {
+		guess = Rank(words, shortlist(words, maxPool), 1)[0].Word
+		s.openers[length] = guess
+	}
+
+	return guess
+}
+
+// shortlist returns at most n of words, preferring those whose letters are
+// the most common in each position among words, since they tend to split
+// the words best.
+func shortlist(words []string, n int) []string {
+	if len(words) <= n {
+		return words
+	}
+
+	length := len(words[0])
+	positional := make([][26]int, length)
+	for _, w := range words {
+		for i := 0; i < length; i++ {
+			positional[i][w[i]-'a']++
+		}
+	}
+
+	score := func(w string) int {
+		var sum int
+		var seen [26]bool
+		for i := 0; i < length; i++ {
+			// Repeated letters reveal less, so only count them once.
+			if !seen[w[i]-'a'] {
+				sum += positional[i][w[i]-'a']
+			}
+			seen[w[i]-'a'] = true
+		}
+		return sum
+	}
+
+	scores := make(map[string]int, len(words))
+	for _, w := range words {
+		scores[w] = score(w)
+	}
+
+	sorted := append([]string(nil), words...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scores[sorted[i]] > scores[sorted[j]]
+	})
+
+	return sorted[:n]
+}
+
+// feedback packs what wording.Evaluate would say about guess into base 3:
+// 0 for absent, 1 for partial, 2 for correct. It mirrors Evaluate without
+// allocating, and both words must be the same length.
+func feedback(answer, guess string) uint64 {
+	var f uint64
+	for i := 0; i < len(guess); i++ {
+		f *= 3
+		switch {
+		case answer[i] == guess[i]:
+			f += 2
+		case strings.IndexByte(answer, guess[i]) >= 0:
+			f++
+		}
+	}
+	return f
+}
+
+// maskedFeedback is feedback with the answer's letters precomputed by
+// letters, which is the hot loop when ranking guesses.
+func maskedFeedback(answer string, mask uint32, guess string) uint64 {
+	var f uint64
+	for i := 0; i < len(guess); i++ {
+		f *= 3
+		switch {
+		case answer[i] == guess[i]:
+			f += 2
+		case mask&(1<<(guess[i]-'a')) != 0:
+			f++
+		}
+	}
+	return f
+}
+
+// letters is the set of letters in a lowercase word.
+func letters(w string) uint32 {
+	var mask uint32
+	for i := 0; i < len(w); i++ {
+		mask |= 1 << (w[i] - 'a')
+	}
+	return mask
+}
+
+func feedbackOf(attempt wording.Attempt) uint64 {
+	var f uint64
+	for _, ch := range attempt {
+		f *= 3
+		switch {
+		case ch.IsCorrect:
+			f += 2
+		case ch.IsPartial:
+			f++
+		}
+	}
+	return f
+}
+
+func isLower(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII || !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(words []string, w string) bool {
+	i := sort.SearchStrings(words, w)
+	return i < len(words) && words[i] == w
+}
+
+// ParseAttempt parses an attempt written as GUESS=FEEDBACK, where each
+// letter of FEEDBACK is g for correct, y for partial and . (or -) for a
+// letter that isn't in the answer, e.g. tomato=yg.ggg.
+func ParseAttempt(s string) (wording.Attempt, error) {
+	guess, marks, ok := strings.Cut(strings.ToLower(s), "=")
+	if !ok || len(guess) != len(marks) || !isLower(guess) {
+		return nil, fmt.Errorf("%q must be GUESS=FEEDBACK with one feedback mark per letter", s)
+	}
+
+	attempt := make(wording.Attempt, 0, len(guess))
+	for i := range guess {
+		ch := wording.Character{Value: guess[i : i+1]}
+
+		switch marks[i] {
+		case 'g':
+			ch.IsCorrect = true
+		case 'y':
+			ch.IsPartial = true
+		case '.', '-':
+		default:
+			return nil, fmt.Errorf("%q: feedback must be g, y or .", s)
+		}
+
+		attempt = append(attempt, ch)
+	}
+
+	return attempt, nil
+}
+
+// FormatAttempt writes an attempt in the notation ParseAttempt reads.
+func FormatAttempt(attempt wording.Attempt) string {
+	var marks strings.Builder
+	for _, ch := range attempt {
+		switch {
+		case ch.IsCorrect:
+			marks.WriteByte('g')
+		case ch.IsPartial:
+			marks.WriteByte('y')
+		default:
+			marks.WriteByte('.')
+		}
+	}
+	return attempt.String() + "=" + marks.String()
+}
@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+var testWords = []string{"potato", "tomato", "banana", "cabana", "bandit", "Potato", "can't"}
+
+func TestFeedbackMatchesEvaluate(t *testing.T) {
+	for _, answer := range testWords[:5] {
+		for _, guess := range testWords[:5] {
+			want := feedbackOf(wording.Evaluate(answer, guess))
+			assert.Equal(t, want, feedback(answer, guess), "%s/%s", answer, guess)
+			assert.Equal(t, want, maskedFeedback(answer, letters(answer), guess), "%s/%s", answer, guess)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	s := New(testWords, nil)
+
+	assert.DeepEqual(t, []string{"banana", "bandit", "cabana", "potato", "tomato"}, s.Words(6))
+	assert.Equal(t, 0, len(s.Words(5)))
+}
+
+func TestCandidates(t *testing.T) {
+	s := New(testWords, nil)
+
+	plays := wording.Plays{Attempts: []string{"banana"}}
+
+	got := s.Candidates(6, plays.Evaluate("potato", 6))
+	assert.DeepEqual(t, []string{"potato", "tomato"}, got)
+
+	got = s.Candidates(6, plays.Evaluate("cabana", 6))
+	assert.DeepEqual(t, []string{"cabana"}, got)
+}
+
+func TestRank(t *testing.T) {
+	candidates := []string{"potato", "tomato", "banana", "cabana"}
+
+	got := Rank(candidates, candidates, 0)
+	assert.Equal(t, len(candidates), len(got))
+
+	// Each guess picks itself out and splits the other three into a single
+	// and a pair: 1/4, 1/4 and 2/4.
+	assert.Equal(t, 1.5, got[0].Bits)
+	assert.Equal(t, 1.5, got[0].Remaining)
+	assert.Equal(t, "banana", got[0].Word)
+
+	got = Rank(candidates, []string{"bandit", "potato"}, 1)
+	assert.DeepEqual(t, []Guess{{Word: "potato", Bits: 1.5, Remaining: 1.5, Candidate: true}}, got)
+
+	for i := 1; i < len(got); i++ {
+		assert.Assert(t, got[i-1].Bits >= got[i].Bits)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	s := New(testWords, nil)
+
+	for _, answer := range []string{"potato", "cabana", "zapata"} {
+		got := s.Solve(answer, 0)
+		assert.Equal(t, answer, got[len(got)-1])
+		assert.Assert(t, len(got) <= 3, "%s took %v", answer, got)
+	}
+
+	got := s.Solve("zapata", 1)
+	assert.Equal(t, 1, len(got))
+}
+
+func TestReadWords(t *testing.T) {
+	words, counts, err := ReadWords(strings.NewReader("# comment\npotato 1234\n\n  Tomato 12\n"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"potato", "Tomato"}, words)
+	assert.DeepEqual(t, map[string]int{"potato": 1234, "tomato": 12}, counts)
+}
+
+func TestRarity(t *testing.T) {
+	s := New([]string{"potato", "tomato", "banana"}, map[string]int{"potato": 100, "tomato": 50, "banana": 1})
+
+	assert.Equal(t, 0.0, s.Rarity("potato"))
+	assert.Equal(t, 0.5, s.Rarity("Tomato"))
+	assert.Equal(t, 1.0, s.Rarity("banana"))
+	assert.Equal(t, 1.0, s.Rarity("zapata"))
+
+	s = New([]string{"potato"}, nil)
+	assert.Equal(t, 0.5, s.Rarity("potato"))
+}
+
+func TestShortlist(t *testing.T) {
+	words := []string{"aaaa", "abcd", "abce", "zzzz"}
+
+	// "abcd" and "abce" share the most common letter in every position
+	// without repeating any.
+	assert.DeepEqual(t, []string{"abcd", "abce"}, shortlist(words, 2))
+}
+
+func TestRater(t *testing.T) {
+	got := NewRater(New(testWords, nil)).RateDifficulty("potato")
+	assert.Assert(t, got.SolverGuesses > 0)
+
+	got = NewRater(nil).RateDifficulty("potato")
+	assert.Equal(t, 0, got.SolverGuesses)
+}
+
+func TestParseAttempt(t *testing.T) {
+	got, err := ParseAttempt("TOMATO=yg.ggg")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, wording.Evaluate("potato", "tomato"), got)
+	assert.Equal(t, "tomato=yg.ggg", FormatAttempt(got))
+
+	_, err = ParseAttempt("tomato=yg")
+	assert.ErrorContains(t, err, "one feedback mark per letter")
+
+	_, err = ParseAttempt("tomato=yg.gx!")
+	assert.ErrorContains(t, err, "feedback must be")
+}
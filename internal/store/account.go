@@ -0,0 +1,229 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// CreateLoginToken saves a login token for the email address until it
+// expires. Tokens and addresses are only ever given to the store hashed.
+// Expired tokens are cleared out at the same time.
+func (s *PostgresStore) CreateLoginToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM login_tokens WHERE expires_at <= NOW()`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO login_tokens (token_hash, email_hash, expires_at) VALUES ($1, $2, $3)
+	`, tokenHash, emailHash, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// StartSession uses up a login token and starts a session for its email
+// address, creating the account with newPlayerToken the first time the
+// address logs in. Either way, playerToken's plays and games are merged
+// into the account, so the browser's own token never becomes the
+// account's. It returns ErrNotFound if the login token doesn't exist or
+// has expired.
+func (s *PostgresStore) StartSession(ctx context.Context, loginTokenHash, playerToken, newPlayerToken, sessionHash string, expiresAt time.Time) (*wording.Account, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(ctx, tx)
+
+	var emailHash string
+	err = tx.QueryRowContext(ctx, `
+	DELETE FROM login_tokens WHERE token_hash = $1 AND expires_at > NOW() RETURNING email_hash
+	`, loginTokenHash).Scan(&emailHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The no-op update makes RETURNING produce the row when the account
+	// already exists.
+	var account wording.Account
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO accounts (email_hash, player_token) VALUES ($1, $2)
+	ON CONFLICT (email_hash) DO UPDATE SET email_hash = EXCLUDED.email_hash
+	RETURNING id, player_token, created_at
+	`, emailHash, newPlayerToken).Scan(&account.ID, &account.PlayerToken, &account.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.PlayerToken != playerToken {
+		err = mergePlayer(ctx, tx, playerToken, account.PlayerToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at <= NOW()`)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO sessions (token_hash, account_id, expires_at) VALUES ($1, $2, $3)
+	`, sessionHash, account.ID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, tx.Commit()
+}
+
+// mergePlayer moves everything from one player token to another. Where
+// both have played the same game, whichever got further is kept.
+func mergePlayer(ctx context.Context, tx *sql.Tx, from, to string) error {
+	for _, query := range []string{
+		`DELETE FROM attempts f USING attempts t
+		WHERE f.player_token = $1 AND t.player_token = $2 AND f.game_token = t.game_token
+		AND cardinality(f.guesses) <= cardinality(t.guesses)`,
+		`DELETE FROM attempts t USING attempts f
+		WHERE f.player_token = $1 AND t.player_token = $2 AND f.game_token = t.game_token`,
+		`UPDATE attempts SET player_token = $2 WHERE player_token = $1`,
+		`UPDATE games SET creator = $2 WHERE creator = $1`,
+	} {
+		_, err := tx.ExecContext(ctx, query, from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AccountSession fetches the account a session is logged in to. It
+// returns ErrNotFound if the session doesn't exist or has expired.
+func (s *PostgresStore) AccountSession(ctx context.Context, sessionHash string) (*wording.Account, error) {
+	var account wording.Account
+	err := s.db.QueryRowContext(ctx, `
+	SELECT a.id, a.player_token, a.created_at
+	FROM sessions s
+	JOIN accounts a ON a.id = s.account_id
+	WHERE s.token_hash = $1 AND s.expires_at > NOW()
+	`, sessionHash).Scan(&account.ID, &account.PlayerToken, &account.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// DeleteSession logs a session out. Deleting a session that doesn't
+// exist isn't an error.
+func (s *PostgresStore) DeleteSession(ctx context.Context, sessionHash string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE token_hash = $1`, sessionHash)
+	return err
+}
+
+// CreatedGames fetches a page of the games created by a player, newest
+// first, along with how many players have played them and won.
+func (s *PostgresStore) CreatedGames(ctx context.Context, creator string, limit, offset int) ([]wording.CreatedGame, error) {
+	// Nobody can guess again once they've won, so a player won if their
+	// last guess is the answer. Answers are encrypted, so that's checked
+	// once they've been opened.
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT
+		g.admin_token, g.token, g.answer, g.answer_key_id, g.guess_limit, g.difficulty, g.solver_guesses, g.created_at,
+		COALESCE(array_agg(a.guesses[cardinality(a.guesses)]) FILTER (WHERE cardinality(a.guesses) > 0), '{}')
+	FROM games g
+	LEFT JOIN attempts a ON a.game_token = g.token
+	WHERE g.creator = $1
+	GROUP BY g.admin_token
+	ORDER BY g.created_at DESC, g.admin_token
+	LIMIT $2 OFFSET $3
+	`, creator, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []wording.CreatedGame
+	for rows.Next() {
+		var (
+			g           wording.CreatedGame
+			keyID       sql.NullString
+			difficulty  gameDifficulty
+			lastGuesses []string
+		)
+
+		dest := append([]any{&g.AdminToken, &g.Token, &g.Answer, &keyID, &g.GuessLimit}, difficulty.dest()...)
+		err := rows.Scan(append(dest, &g.CreatedAt, pq.Array(&lastGuesses))...)
+		if err != nil {
+			return nil, err
+		}
+		g.Difficulty = difficulty.difficulty()
+
+		g.Answer, err = s.openAnswer(g.AdminToken, keyID, g.Answer)
+		if err != nil {
+			return nil, err
+		}
+
+		g.Players = len(lastGuesses)
+		for _, guess := range lastGuesses {
+			if guess == g.Answer {
+				g.Wins++
+			}
+		}
+
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// DeleteCreatedGames deletes those of the games that were created by the
+// player, along with their attempts, and returns how many were deleted.
+// Games created by anyone else are left alone.
+func (s *PostgresStore) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, `
+	DELETE FROM attempts WHERE game_token IN (SELECT token FROM games WHERE creator = $1 AND admin_token = ANY($2))
+	`, creator, pq.Array(adminTokens))
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM games WHERE creator = $1 AND admin_token = ANY($2)`, creator, pq.Array(adminTokens))
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), tx.Commit()
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchGames fetches a page of the games matching search, along with how
+// many players have played them. Answers aren't included.
+func (s *PostgresStore) SearchGames(ctx context.Context, search wording.GameSearch, limit, offset int) ([]wording.ListedGame, error) {
+	order := "g.created_at"
+	if search.ByActivity {
+		order = "g.accessed_at"
+	}
+
+	var prefix string
+	if search.Token != "" {
+		prefix = likeEscaper.Replace(search.Token) + "%"
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+	SELECT
+		g.admin_token, g.token, g.guess_limit, g.difficulty, g.solver_guesses, g.disabled_at IS NOT NULL,
+		g.created_at, g.accessed_at, COUNT(a.player_token)
+	FROM games g
+	LEFT JOIN attempts a ON a.game_token = g.token
+	WHERE ($1 = '' OR g.token LIKE $1 OR g.admin_token LIKE $1)
+	AND ($2::timestamptz IS NULL OR g.created_at >= $2)
+	AND ($3::timestamptz IS NULL OR g.created_at < $3)
+	GROUP BY g.admin_token
+	ORDER BY %s DESC, g.admin_token
+	LIMIT $4 OFFSET $5
+	`, order),
+		prefix,
+		nullTime(search.CreatedFrom),
+		nullTime(search.CreatedBefore),
+		limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []wording.ListedGame
+	for rows.Next() {
+		var (
+			g          wording.ListedGame
+			difficulty gameDifficulty
+		)
+
+		dest := append([]any{&g.AdminToken, &g.Token, &g.GuessLimit}, difficulty.dest()...)
+		err := rows.Scan(append(dest, &g.Disabled, &g.CreatedAt, &g.AccessedAt, &g.Players)...)
+		if err != nil {
+			return nil, err
+		}
+		g.Difficulty = difficulty.difficulty()
+
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// InspectGame fetches a game and its plays for an operator, once it has
+// recorded that they looked. Unlike Game, it doesn't count as activity.
+func (s *PostgresStore) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(ctx, tx)
+
+	err = audit(ctx, tx, operator, wording.AuditViewGame, adminToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GameRecord(ctx, adminToken)
+}
+
+// SetGameDisabled disables or enables a game on an operator's behalf and
+// records it in the audit log.
+func (s *PostgresStore) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	action := wording.AuditEnableGame
+	if disabled {
+		action = wording.AuditDisableGame
+	}
+
+	err = audit(ctx, tx, operator, action, adminToken)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE games
+	SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, NOW()) END, modified_at = NOW()
+	WHERE admin_token = $1
+	`, adminToken, disabled)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// OperatorDeleteGame deletes a game and its attempts on an operator's
+// behalf and records it in the audit log.
+func (s *PostgresStore) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	err = audit(ctx, tx, operator, wording.AuditDeleteGame, adminToken)
+	if err != nil {
+		return err
+	}
+
+	err = deleteGame(ctx, tx, adminToken)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AuditLog fetches the most recent audit log entries, newest first.
+func (s *PostgresStore) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT created_at, operator, action, game_token FROM audit_log ORDER BY id DESC LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []wording.AuditEntry
+	for rows.Next() {
+		var e wording.AuditEntry
+		err := rows.Scan(&e.Time, &e.Operator, &e.Action, &e.GameToken)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// audit records an operator's action on a game in the same transaction as
+// the action itself. It returns ErrNotFound if there is no such game.
+func audit(ctx context.Context, tx *sql.Tx, operator, action, adminToken string) error {
+	res, err := tx.ExecContext(ctx, `
+	INSERT INTO audit_log (operator, action, game_token)
+	SELECT $1, $2, token FROM games WHERE admin_token = $3
+	`, operator, action, adminToken)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNoAnswerKeys means an answer is encrypted but the store wasn't given
+// any keys to decrypt it with.
+var ErrNoAnswerKeys = errors.New("answer is encrypted but no answer keys are configured")
+
+// sealAnswer encrypts an answer for storage, tied to its game's admin
+// token. Without a sealer the answer is stored as is, with a NULL key ID.
+func (s *PostgresStore) sealAnswer(adminToken, answer string) (sql.NullString, string, error) {
+	if s.sealer == nil {
+		return sql.NullString{}, answer, nil
+	}
+
+	keyID, sealed, err := s.sealer.Seal(answer, adminToken)
+	if err != nil {
+		return sql.NullString{}, "", err
+	}
+
+	return sql.NullString{String: keyID, Valid: true}, sealed, nil
+}
+
+// openAnswer reverses sealAnswer.
+func (s *PostgresStore) openAnswer(adminToken string, keyID sql.NullString, stored string) (string, error) {
+	if !keyID.Valid {
+		return stored, nil
+	}
+	if s.sealer == nil {
+		return "", ErrNoAnswerKeys
+	}
+
+	return s.sealer.Open(keyID.String, stored, adminToken)
+}
+
+// RotateKeys re-encrypts every answer that isn't encrypted with the
+// current key, including plaintext ones, and returns how many it changed.
+// If decrypt is set, answers are stored as plaintext instead, e.g., before
+// reverting the migration that added key IDs.
+//
+// Each answer is updated on its own, and only if nobody else has changed
+// it in the meantime, so it's safe to run while the server is up.
+func (s *PostgresStore) RotateKeys(ctx context.Context, decrypt bool) (int, error) {
+	if s.sealer == nil {
+		return 0, ErrNoAnswerKeys
+	}
+
+	var target sql.NullString
+	if !decrypt {
+		target = sql.NullString{String: s.sealer.KeyID(), Valid: true}
+	}
+
+	type row struct {
+		adminToken string
+		answer     string
+		keyID      sql.NullString
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT admin_token, answer, answer_key_id FROM games WHERE answer_key_id IS DISTINCT FROM $1`,
+		target,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var stale []row
+	for rows.Next() {
+		var r row
+		err := rows.Scan(&r.adminToken, &r.answer, &r.keyID)
+		if err != nil {
+			return 0, err
+		}
+		stale = append(stale, r)
+	}
+	err = rows.Err()
+	if err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	rotated := 0
+	for _, r := range stale {
+		answer, err := s.openAnswer(r.adminToken, r.keyID, r.answer)
+		if err != nil {
+			return rotated, err
+		}
+
+		keyID, stored := sql.NullString{}, answer
+		if !decrypt {
+			keyID, stored, err = s.sealAnswer(r.adminToken, answer)
+			if err != nil {
+				return rotated, err
+			}
+		}
+
+		res, err := s.db.ExecContext(ctx, `
+		UPDATE games SET answer = $2, answer_key_id = $3
+		WHERE admin_token = $1 AND answer = $4 AND answer_key_id IS NOT DISTINCT FROM $5
+		`, r.adminToken, stored, keyID, r.answer, r.keyID)
+		if err != nil {
+			return rotated, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return rotated, err
+		}
+		rotated += int(n)
+	}
+
+	return rotated, nil
+}
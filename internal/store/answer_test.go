@@ -0,0 +1,48 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/sealer"
+)
+
+func TestSealAnswer(t *testing.T) {
+	sl, err := sealer.New(sealer.Key{ID: "2023", Secret: []byte(strings.Repeat("k", 32))})
+	assert.NilError(t, err)
+
+	s := &PostgresStore{sealer: sl}
+
+	keyID, stored, err := s.sealAnswer("wretched-apostle", "potato")
+	assert.NilError(t, err)
+	assert.Equal(t, sql.NullString{String: "2023", Valid: true}, keyID)
+	assert.Assert(t, stored != "potato")
+
+	got, err := s.openAnswer("wretched-apostle", keyID, stored)
+	assert.NilError(t, err)
+	assert.Equal(t, "potato", got)
+
+	_, err = s.openAnswer("hungry-hippo", keyID, stored)
+	assert.Assert(t, errors.Is(err, sealer.ErrInvalidSealed), err)
+
+	// Answers stored before encryption was set up are read as they are.
+	got, err = s.openAnswer("wretched-apostle", sql.NullString{}, "tomato")
+	assert.NilError(t, err)
+	assert.Equal(t, "tomato", got)
+}
+
+func TestSealAnswerWithoutKeys(t *testing.T) {
+	s := &PostgresStore{}
+
+	keyID, stored, err := s.sealAnswer("wretched-apostle", "potato")
+	assert.NilError(t, err)
+	assert.Assert(t, !keyID.Valid)
+	assert.Equal(t, "potato", stored)
+
+	_, err = s.openAnswer("wretched-apostle", sql.NullString{String: "2023", Valid: true}, "c2VhbGVk")
+	assert.Equal(t, ErrNoAnswerKeys, err)
+}
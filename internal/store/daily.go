@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// today is the day that stats are being recorded for, in UTC to match
+// wording.Day.
+const today = `(NOW() AT TIME ZONE 'UTC')::date`
+
+// recordGameCreated counts a new game in today's stats.
+func recordGameCreated(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO daily_stats (day, games_created) VALUES (`+today+`, 1)
+	ON CONFLICT (day) DO UPDATE SET games_created = daily_stats.games_created + 1
+	`)
+	return err
+}
+
+// recordPlay counts a guess in today's stats, both for the game it was
+// made against and for every game. guesses is how many guesses the player
+// has made so far.
+func recordPlay(ctx context.Context, tx *sql.Tx, gameToken string, guesses int, outcome wording.PlayOutcome) error {
+	started, won := 0, 0
+	if outcome.Started {
+		started = 1
+	}
+	if outcome.Won {
+		won = 1
+	}
+
+	const increment = `
+	players_started = players_started + $1,
+	guesses_made = guesses_made + 1,
+	games_won = games_won + $2,
+	wins_in[$3] = wins_in[$3] + $2
+	`
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO daily_stats (day) VALUES (`+today+`) ON CONFLICT (day) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE daily_stats SET `+increment+` WHERE day = `+today, started, won, guesses)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO game_daily_stats (admin_token, day)
+	SELECT admin_token, `+today+` FROM games WHERE token = $1
+	ON CONFLICT (admin_token, day) DO NOTHING
+	`, gameToken)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE game_daily_stats SET `+increment+`
+	WHERE admin_token = (SELECT admin_token FROM games WHERE token = $4) AND day = `+today,
+		started, won, guesses, gameToken)
+	return err
+}
+
+// DailyStats fetches the stats for every game for each day since the
+// given one, oldest first. Days without any activity are left out.
+func (s *PostgresStore) DailyStats(ctx context.Context, since time.Time) ([]wording.DailyStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT day, games_created, players_started, guesses_made, games_won, wins_in
+	FROM daily_stats WHERE day >= $1 ORDER BY day
+	`, wording.Day(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDailyStats(rows)
+}
+
+// GameDailyStats fetches a game's stats for each day since the given one,
+// oldest first. Days without any activity are left out.
+func (s *PostgresStore) GameDailyStats(ctx context.Context, adminToken string, since time.Time) ([]wording.DailyStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT day, 0, players_started, guesses_made, games_won, wins_in
+	FROM game_daily_stats WHERE admin_token = $1 AND day >= $2 ORDER BY day
+	`, adminToken, wording.Day(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDailyStats(rows)
+}
+
+func scanDailyStats(rows *sql.Rows) ([]wording.DailyStats, error) {
+	var days []wording.DailyStats
+
+	for rows.Next() {
+		var (
+			d      wording.DailyStats
+			winsIn []int64
+		)
+
+		err := rows.Scan(&d.Day, &d.GamesCreated, &d.PlayersStarted, &d.GuessesMade, &d.GamesWon, pq.Array(&winsIn))
+		if err != nil {
+			return nil, err
+		}
+
+		d.Day = wording.Day(d.Day)
+		d.WinsIn = trimWinsIn(winsIn)
+		days = append(days, d)
+	}
+
+	return days, rows.Err()
+}
+
+// trimWinsIn drops the zeros past the most guesses anybody won in, since
+// the column is always as long as the highest guess limit.
+func trimWinsIn(winsIn []int64) []int {
+	n := len(winsIn)
+	for n > 0 && winsIn[n-1] == 0 {
+		n--
+	}
+
+	trimmed := make([]int, n)
+	for i := range trimmed {
+		trimmed[i] = int(winsIn[i])
+	}
+
+	return trimmed
+}
@@ -0,0 +1,275 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// ErrConflict indicates the record clashes with a different existing one.
+var ErrConflict = errors.New("conflict")
+
+const exportQuery = `
+	SELECT
+		g.admin_token,
+		g.token,
+		g.answer,
+		g.answer_key_id,
+		g.guess_limit,
+		g.created_at,
+		g.opens_at,
+		g.closes_at,
+		g.reveal,
+		g.disabled_at IS NOT NULL,
+		g.creator,
+		g.recovery_email_hash,
+		g.difficulty,
+		g.solver_guesses,
+		a.player_token,
+		a.created_at,
+		a.guesses
+	FROM games g
+	LEFT JOIN attempts a ON a.game_token = g.token
+	`
+
+// ExportGames calls fn with every game and its plays, oldest first. Games
+// are streamed from the database rather than loaded all at once.
+func (s *PostgresStore) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	rows, err := s.db.QueryContext(ctx, exportQuery+`ORDER BY g.created_at, g.admin_token, a.created_at, a.player_token`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return s.scanGameRecords(rows, fn)
+}
+
+// GameRecord fetches a game and its plays.
+func (s *PostgresStore) GameRecord(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	rows, err := s.db.QueryContext(ctx, exportQuery+`WHERE g.admin_token = $1 ORDER BY a.created_at, a.player_token`, adminToken)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var record *wording.GameRecord
+	err = s.scanGameRecords(rows, func(r *wording.GameRecord) error {
+		record = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrNotFound
+	}
+
+	return record, nil
+}
+
+// scanGameRecords groups the rows of exportQuery, which must be ordered by
+// game, into one record per game.
+func (s *PostgresStore) scanGameRecords(rows *sql.Rows, fn func(*wording.GameRecord) error) error {
+	var current *wording.GameRecord
+
+	for rows.Next() {
+		var (
+			game        wording.GameRecord
+			keyID       sql.NullString
+			difficulty  gameDifficulty
+			settings    gameSettings
+			playerToken sql.NullString
+			playedAt    sql.NullTime
+			guesses     []string
+			creator     sql.NullString
+			emailHash   sql.NullString
+		)
+
+		dest := append([]any{&game.AdminToken, &game.Token, &game.Answer, &keyID, &game.GuessLimit, &game.CreatedAt}, settings.dest()...)
+		dest = append(append(dest, &game.Disabled, &creator, &emailHash), difficulty.dest()...)
+		err := rows.Scan(append(dest, &playerToken, &playedAt, pq.Array(&guesses))...)
+		if err != nil {
+			return err
+		}
+
+		if current == nil || current.AdminToken != game.AdminToken {
+			if current != nil {
+				err := fn(current)
+				if err != nil {
+					return err
+				}
+			}
+
+			game.Answer, err = s.openAnswer(game.AdminToken, keyID, game.Answer)
+			if err != nil {
+				return err
+			}
+
+			game.Difficulty = difficulty.difficulty()
+			game.GameSettings = settings.settings()
+			game.Creator = creator.String
+			game.RecoveryEmailHash = emailHash.String
+			current = &game
+		}
+
+		if playerToken.Valid {
+			current.Players = append(current.Players, wording.PlayerRecord{
+				PlayerToken: playerToken.String,
+				CreatedAt:   playedAt.Time,
+				Plays:       wording.Plays{Attempts: guesses},
+			})
+		}
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if current != nil {
+		return fn(current)
+	}
+
+	return nil
+}
+
+// ImportGame creates or replaces the game with the record's admin token,
+// and creates or replaces each of the record's players' attempts. Attempts
+// by other players are left alone, so importing the same record twice
+// changes nothing. It returns ErrConflict if a different game already has
+// the record's player token.
+func (s *PostgresStore) ImportGame(ctx context.Context, record *wording.GameRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	var taken bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM games WHERE token = $1 AND admin_token <> $2)`,
+		record.Token, record.AdminToken,
+	).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrConflict
+	}
+
+	// If the game is being given a new player token, its attempts have to
+	// follow it.
+	_, err = tx.ExecContext(ctx, `
+	UPDATE attempts SET game_token = $2
+	WHERE game_token = (SELECT token FROM games WHERE admin_token = $1 AND token <> $2)
+	`, record.AdminToken, record.Token)
+	if err != nil {
+		return err
+	}
+
+	createdAt := record.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	keyID, sealed, err := s.sealAnswer(record.AdminToken, record.Answer)
+	if err != nil {
+		return err
+	}
+
+	var score, solverGuesses sql.NullInt64
+	if d := record.Difficulty; d != nil {
+		score = sql.NullInt64{Int64: int64(d.Score), Valid: true}
+		solverGuesses = sql.NullInt64{Int64: int64(d.SolverGuesses), Valid: d.SolverGuesses > 0}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO games (
+		admin_token,
+		token,
+		answer,
+		answer_key_id,
+		guess_limit,
+		created_at,
+		difficulty,
+		solver_guesses,
+		opens_at,
+		closes_at,
+		reveal,
+		creator,
+		recovery_email_hash,
+		disabled_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $10, $11, $12, $13, $14, CASE WHEN $9::boolean THEN NOW() END
+	) ON CONFLICT (admin_token) DO UPDATE SET
+		token = $2,
+		answer = $3,
+		answer_key_id = $4,
+		guess_limit = $5,
+		created_at = $6,
+		difficulty = $7,
+		solver_guesses = $8,
+		disabled_at = CASE WHEN $9::boolean THEN COALESCE(games.disabled_at, NOW()) END,
+		opens_at = $10,
+		closes_at = $11,
+		reveal = $12,
+		creator = $13,
+		recovery_email_hash = $14,
+		modified_at = NOW()
+	`, record.AdminToken, record.Token, sealed, keyID, record.GuessLimit, createdAt, score, solverGuesses, record.Disabled,
+		nullTime(record.OpensAt), nullTime(record.ClosesAt), record.Reveal,
+		sql.NullString{String: record.Creator, Valid: record.Creator != ""},
+		sql.NullString{String: record.RecoveryEmailHash, Valid: record.RecoveryEmailHash != ""})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range record.Players {
+		playedAt := p.CreatedAt
+		if playedAt.IsZero() {
+			playedAt = createdAt
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO attempts (
+			game_token,
+			player_token,
+			created_at,
+			guesses
+		) VALUES (
+			$1, $2, $3, $4
+		) ON CONFLICT (game_token, player_token) DO UPDATE SET
+			created_at = $3,
+			guesses = $4
+		`, record.Token, p.PlayerToken, playedAt, pq.Array(p.Attempts))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetStats overwrites the lifetime stats.
+func (s *PostgresStore) SetStats(ctx context.Context, stats wording.Stats) error {
+	query := `INSERT INTO stats (
+		scope,
+		games_created,
+		games_won,
+		guesses_made
+	) VALUES (
+		$1, $2, $3, $4
+	) ON CONFLICT (scope) DO UPDATE SET
+	games_created = $2,
+	games_won = $3,
+	guesses_made = $4`
+	args := []any{wording.LifetimeScope, stats.GamesCreated, stats.GamesWon, stats.GuessesMade}
+
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestImportGameKeepsCreator(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	creator := testToken("creator")
+	game := createTestGame(t, s, wording.GameSettings{}, creator)
+
+	record, err := s.GameRecord(ctx, game.AdminToken)
+	assert.NilError(t, err)
+	assert.Equal(t, creator, record.Creator)
+
+	// Restoring a backup into an empty database.
+	assert.NilError(t, s.DeleteGame(ctx, game.AdminToken))
+	assert.NilError(t, s.ImportGame(ctx, record))
+
+	created, err := s.CreatedGames(ctx, creator, 10, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(created))
+	assert.Equal(t, game.AdminToken, created[0].AdminToken)
+}
+
+func TestImportGameKeepsRecoveryEmail(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	game := createTestGame(t, s, wording.GameSettings{}, "")
+	emailHash := testToken("email")
+	assert.NilError(t, s.SetRecoveryEmail(ctx, game.AdminToken, emailHash))
+
+	record, err := s.GameRecord(ctx, game.AdminToken)
+	assert.NilError(t, err)
+	assert.Equal(t, emailHash, record.RecoveryEmailHash)
+
+	assert.NilError(t, s.DeleteGame(ctx, game.AdminToken))
+	assert.NilError(t, s.ImportGame(ctx, record))
+
+	recoverable, err := s.RecoverableGames(ctx, emailHash)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(recoverable))
+	assert.Equal(t, game.AdminToken, recoverable[0].AdminToken)
+}
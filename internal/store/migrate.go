@@ -0,0 +1,216 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ErrDirty indicates a previous migration failed part way through and the
+// schema has to be repaired by hand.
+var ErrDirty = errors.New("database schema is dirty")
+
+// Migration is a single schema change.
+type Migration struct {
+	Version uint
+	Name    string
+	up      string
+	down    string
+}
+
+var migrationName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// LoadMigrations reads the migrations in fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, e := range entries {
+		m := migrationName.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[uint(version)]
+		if !ok {
+			mig = &Migration{Version: uint(version), Name: m[2]}
+			byVersion[uint(version)] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %q and %q", version, mig.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mig.up = string(b)
+		} else {
+			mig.down = string(b)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ensureMigrationsTable creates the bookkeeping table if needed. It has the
+// same layout as golang-migrate's so databases migrated by hand keep
+// working.
+func (s *PostgresStore) ensureMigrationsTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint NOT NULL PRIMARY KEY,
+		dirty boolean NOT NULL
+	)
+	`)
+	return err
+}
+
+// MigrationVersion returns the version of the last applied migration, or
+// zero if none have been applied.
+func (s *PostgresStore) MigrationVersion(ctx context.Context) (version uint, dirty bool, err error) {
+	err = s.ensureMigrationsTable(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`)
+	err = row.Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+// MigrateUp applies every migration newer than the current version, each in
+// its own transaction, and returns the ones it applied.
+func (s *PostgresStore) MigrateUp(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	current, err := s.cleanVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+
+		err := s.migrate(ctx, m.up, &m.Version)
+		if err != nil {
+			return applied, fmt.Errorf("applying %d_%s: %w", m.Version, m.Name, err)
+		}
+
+		applied = append(applied, m)
+	}
+
+	return applied, nil
+}
+
+// MigrateDown reverts up to steps of the most recently applied migrations
+// and returns the ones it reverted.
+func (s *PostgresStore) MigrateDown(ctx context.Context, migrations []Migration, steps int) ([]Migration, error) {
+	current, err := s.cleanVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		m := migrations[i]
+		if m.Version > current {
+			continue
+		}
+
+		if m.down == "" {
+			return reverted, fmt.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+
+		var previous *uint
+		if i > 0 {
+			previous = &migrations[i-1].Version
+		}
+
+		err := s.migrate(ctx, m.down, previous)
+		if err != nil {
+			return reverted, fmt.Errorf("reverting %d_%s: %w", m.Version, m.Name, err)
+		}
+
+		reverted = append(reverted, m)
+	}
+
+	return reverted, nil
+}
+
+func (s *PostgresStore) cleanVersion(ctx context.Context) (uint, error) {
+	current, dirty, err := s.MigrationVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d", ErrDirty, current)
+	}
+
+	return current, nil
+}
+
+// migrate runs a migration's SQL and records the resulting version in the
+// same transaction, so a failure leaves the schema untouched. A nil version
+// means no migrations remain applied.
+func (s *PostgresStore) migrate(ctx context.Context, query string, version *uint) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`)
+	if err != nil {
+		return err
+	}
+
+	if version != nil {
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, *version)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_b.up.sql":   {Data: []byte("up b")},
+		"000002_b.down.sql": {Data: []byte("down b")},
+		"000001_a.up.sql":   {Data: []byte("up a")},
+		"README.md":         {Data: []byte("ignored")},
+	}
+
+	got, err := LoadMigrations(fsys)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(got))
+	assert.Equal(t, Migration{Version: 1, Name: "a", up: "up a"}, got[0])
+	assert.Equal(t, Migration{Version: 2, Name: "b", up: "up b", down: "down b"}, got[1])
+}
+
+func TestLoadMigrationsRejectsMissingUp(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_a.down.sql": {Data: []byte("down a")},
+	}
+
+	_, err := LoadMigrations(fsys)
+	assert.ErrorContains(t, err, "no up file")
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	got, err := LoadMigrations(migrations.FS)
+	assert.NilError(t, err)
+	assert.Assert(t, len(got) > 0)
+
+	for i, m := range got {
+		assert.Equal(t, uint(i+1), m.Version, "migrations must be numbered without gaps")
+		assert.Assert(t, m.down != "", "%d_%s has no down file", m.Version, m.Name)
+	}
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// PlayerGames fetches every game a player has made attempts at, oldest
+// first.
+func (s *PostgresStore) PlayerGames(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT
+		g.admin_token, g.token, g.answer, g.answer_key_id, g.guess_limit, g.opens_at, g.closes_at, g.reveal,
+		a.created_at, a.guesses
+	FROM attempts a
+	JOIN games g ON g.token = a.game_token
+	WHERE a.player_token = $1
+	ORDER BY a.created_at, g.token
+	`, playerToken)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []wording.PlayerGame
+	for rows.Next() {
+		var (
+			g          wording.PlayerGame
+			adminToken string
+			keyID      sql.NullString
+			settings   gameSettings
+		)
+
+		dest := append([]any{&adminToken, &g.Token, &g.Answer, &keyID, &g.GuessLimit}, settings.dest()...)
+		err := rows.Scan(append(dest, &g.StartedAt, pq.Array(&g.Attempts))...)
+		if err != nil {
+			return nil, err
+		}
+		g.GameSettings = settings.settings()
+
+		g.Answer, err = s.openAnswer(adminToken, keyID, g.Answer)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// DeletePlayerGames deletes all of a player's attempts and returns how
+// many games they were for. The stats they were counted in are left
+// alone.
+func (s *PostgresStore) DeletePlayerGames(ctx context.Context, playerToken string) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM attempts WHERE player_token = $1`, playerToken)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
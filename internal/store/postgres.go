@@ -7,17 +7,23 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/sealer"
 	"github.com/connorkuehl/wording/internal/wording"
 )
 
 // PostgresStore is a PostgreSQL-backed persistence layer for the game.
 type PostgresStore struct {
-	db *sql.DB
+	db     *sql.DB
+	sealer *sealer.Sealer
+	log    logrus.FieldLogger
 }
 
-// NewPostgresStore opens a connection to the Postgres store.
-func NewPostgresStore(dsn string) (*PostgresStore, error) {
+// NewPostgresStore opens a connection to the Postgres store. Answers are
+// encrypted with the sealer; if it's nil they're stored in plaintext.
+func NewPostgresStore(dsn string, sealer *sealer.Sealer, logger logrus.FieldLogger) (*PostgresStore, error) {
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, err
@@ -33,7 +39,9 @@ func NewPostgresStore(dsn string) (*PostgresStore, error) {
 	}
 
 	s := &PostgresStore{
-		db: db,
+		db:     db,
+		sealer: sealer,
+		log:    logger,
 	}
 
 	return s, nil
@@ -44,56 +52,175 @@ func (s *PostgresStore) Close() error {
 	return s.db.Close()
 }
 
-// CreateGame creates a game.
-func (s *PostgresStore) CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int) (*wording.Game, error) {
+// rollback aborts a transaction that wasn't committed. It's meant to be
+// deferred right after the transaction begins.
+func (s *PostgresStore) rollback(ctx context.Context, tx *sql.Tx) {
+	err := tx.Rollback()
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logging.From(ctx, s.log).WithError(err).Warn("rolling back transaction")
+	}
+}
+
+// Ping checks that the database is reachable.
+func (s *PostgresStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// CreateGame creates a game. creator is the player token of whoever
+// created it, if anyone.
+func (s *PostgresStore) CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string) (*wording.Game, error) {
 	query := `
 	INSERT INTO games (
 		admin_token,
 		token,
 		answer,
-		guess_limit
+		answer_key_id,
+		guess_limit,
+		difficulty,
+		solver_guesses,
+		opens_at,
+		closes_at,
+		reveal,
+		creator
 	) VALUES (
 		$1,
 		$2,
 		$3,
-		$4
+		$4,
+		$5,
+		$6,
+		$7,
+		$8,
+		$9,
+		$10,
+		$11
 	)
 	`
 
-	_, err := s.db.ExecContext(ctx, query, adminToken, token, answer, guessLimit)
+	keyID, sealed, err := s.sealAnswer(adminToken, answer)
+	if err != nil {
+		return nil, err
+	}
+
+	solverGuesses := sql.NullInt64{Int64: int64(difficulty.SolverGuesses), Valid: difficulty.SolverGuesses > 0}
+	createdBy := sql.NullString{String: creator, Valid: creator != ""}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, query, adminToken, token, sealed, keyID, guessLimit, difficulty.Score, solverGuesses,
+		nullTime(settings.OpensAt), nullTime(settings.ClosesAt), settings.Reveal, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordGameCreated(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
 	game := &wording.Game{
-		AdminToken: adminToken,
-		Token:      token,
-		Answer:     answer,
-		GuessLimit: guessLimit,
+		AdminToken:   adminToken,
+		Token:        token,
+		Answer:       answer,
+		GuessLimit:   guessLimit,
+		Difficulty:   &difficulty,
+		GameSettings: settings,
 	}
 
 	return game, nil
 }
 
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// gameDifficulty is scanned alongside a game. Games created before
+// answers were rated have no difficulty.
+type gameDifficulty struct {
+	score         sql.NullInt64
+	solverGuesses sql.NullInt64
+}
+
+func (d *gameDifficulty) dest() []any {
+	return []any{&d.score, &d.solverGuesses}
+}
+
+func (d *gameDifficulty) difficulty() *wording.Difficulty {
+	if !d.score.Valid {
+		return nil
+	}
+	return &wording.Difficulty{
+		Score:         int(d.score.Int64),
+		SolverGuesses: int(d.solverGuesses.Int64),
+	}
+}
+
+// gameSettings is scanned alongside a game.
+type gameSettings struct {
+	opensAt  sql.NullTime
+	closesAt sql.NullTime
+	reveal   string
+}
+
+func (g *gameSettings) dest() []any {
+	return []any{&g.opensAt, &g.closesAt, &g.reveal}
+}
+
+func (g *gameSettings) settings() wording.GameSettings {
+	return wording.GameSettings{
+		OpensAt:  g.opensAt.Time,
+		ClosesAt: g.closesAt.Time,
+		Reveal:   wording.Reveal(g.reveal),
+	}
+}
+
 // Game fetches a game.
 func (s *PostgresStore) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
 
-	query := `SELECT token, answer, guess_limit FROM games WHERE admin_token = $1`
+	query := `
+	SELECT
+		token, answer, answer_key_id, guess_limit, difficulty, solver_guesses, opens_at, closes_at, reveal,
+		recovery_email_hash IS NOT NULL, disabled_at IS NOT NULL
+	FROM games
+	WHERE admin_token = $1
+	`
 
 	game := wording.Game{AdminToken: adminToken}
+	var (
+		keyID      sql.NullString
+		difficulty gameDifficulty
+		settings   gameSettings
+	)
+	dest := append(append([]any{&game.Token, &game.Answer, &keyID, &game.GuessLimit}, difficulty.dest()...), settings.dest()...)
 	err = tx.QueryRowContext(ctx, query, adminToken).
-		Scan(&game.Token, &game.Answer, &game.GuessLimit)
+		Scan(append(dest, &game.Recoverable, &game.Disabled)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		err = ErrNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+	game.Difficulty = difficulty.difficulty()
+	game.GameSettings = settings.settings()
+
+	game.Answer, err = s.openAnswer(adminToken, keyID, game.Answer)
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE games SET accessed_at = NOW() WHERE admin_token = $1`, adminToken)
 	if err != nil {
@@ -109,21 +236,38 @@ func (s *PostgresStore) GameByToken(ctx context.Context, token string) (*wording
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
 
-	query := `SELECT admin_token, answer, guess_limit FROM games WHERE token = $1`
+	query := `
+	SELECT admin_token, answer, answer_key_id, guess_limit, difficulty, solver_guesses, opens_at, closes_at, reveal, disabled_at IS NOT NULL
+	FROM games
+	WHERE token = $1
+	`
 
 	game := wording.Game{
 		Token: token,
 	}
+	var (
+		keyID      sql.NullString
+		difficulty gameDifficulty
+		settings   gameSettings
+	)
+	dest := append(append([]any{&game.AdminToken, &game.Answer, &keyID, &game.GuessLimit}, difficulty.dest()...), settings.dest()...)
 	err = tx.QueryRowContext(ctx, query, token).
-		Scan(&game.AdminToken, &game.Answer, &game.GuessLimit)
+		Scan(append(dest, &game.Disabled)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		err = ErrNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+	game.Difficulty = difficulty.difficulty()
+	game.GameSettings = settings.settings()
+
+	game.Answer, err = s.openAnswer(game.AdminToken, keyID, game.Answer)
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE games SET accessed_at = NOW() WHERE token = $1`, token)
 	if err != nil {
@@ -139,7 +283,7 @@ func (s *PostgresStore) Plays(ctx context.Context, gameToken, playerToken string
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
 
 	plays := &wording.Plays{}
 
@@ -157,13 +301,14 @@ func (s *PostgresStore) Plays(ctx context.Context, gameToken, playerToken string
 	return plays, tx.Commit()
 }
 
-// PutPlays updates a player's attempts against a game.
-func (s *PostgresStore) PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays) error {
+// PutPlays updates a player's attempts against a game, and counts the
+// latest one in the daily stats.
+func (s *PostgresStore) PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
 
 	query := `INSERT INTO attempts (
 		game_token,
@@ -190,6 +335,11 @@ func (s *PostgresStore) PutPlays(ctx context.Context, gameToken, playerToken str
 		return err
 	}
 
+	err = recordPlay(ctx, tx, gameToken, len(plays.Attempts), outcome)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -229,42 +379,80 @@ func (s *PostgresStore) Stats(ctx context.Context) (wording.Stats, error) {
 	return stats, nil
 }
 
-// GameStats fetches stats for an individual game.
-func (s *PostgresStore) GameStats(ctx context.Context, adminToken string) (wording.Stats, error) {
-	var stats wording.Stats
+// GameStats fetches stats for an individual game. Answers are encrypted,
+// so they're worked out here rather than in SQL.
+func (s *PostgresStore) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	var stats wording.GameStats
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return stats, err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
+
+	var (
+		token, answer string
+		keyID         sql.NullString
+		guessLimit    int
+	)
+	query := `SELECT token, answer, answer_key_id, guess_limit FROM games WHERE admin_token = $1`
+	err = tx.QueryRowContext(ctx, query, adminToken).Scan(&token, &answer, &keyID, &guessLimit)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return stats, err
+	}
 
-	query := `SELECT COUNT(*)
-	FROM attempts WHERE
-	game_token = (SELECT token FROM games WHERE admin_token = $1) AND
-	(SELECT answer FROM games WHERE admin_token = $1) = ANY (guesses);`
-	err = tx.QueryRowContext(ctx, query, adminToken).Scan(&stats.GamesWon)
+	answer, err = s.openAnswer(adminToken, keyID, answer)
 	if err != nil {
 		return stats, err
 	}
-	query = `SELECT array_length(guesses, 1) FROM attempts WHERE game_token = (SELECT token FROM games WHERE admin_token = $1)`
-	rows, err := tx.QueryContext(ctx, query, adminToken)
+
+	query = `SELECT guesses FROM attempts WHERE game_token = $1`
+	rows, err := tx.QueryContext(ctx, query, token)
 	if err != nil {
 		return stats, err
 	}
 	defer rows.Close()
 
+	var players []wording.Plays
 	for rows.Next() {
-		var playerGuesses int
-		err := rows.Scan(&playerGuesses)
+		var p wording.Plays
+		err := rows.Scan(pq.Array(&p.Attempts))
 		if err != nil {
 			return stats, err
 		}
 
-		stats.GuessesMade += playerGuesses
+		players = append(players, p)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return stats, err
+	}
+
+	return wording.TallyGameStats(answer, guessLimit, players), tx.Commit()
+}
+
+// UpdateGameSettings replaces a game's settings.
+func (s *PostgresStore) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	res, err := s.db.ExecContext(ctx, `
+	UPDATE games SET opens_at = $2, closes_at = $3, reveal = $4, modified_at = NOW() WHERE admin_token = $1
+	`, adminToken, nullTime(settings.OpensAt), nullTime(settings.ClosesAt), settings.Reveal)
+	if err != nil {
+		return err
 	}
 
-	return stats, tx.Commit()
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
 }
 
 // DeleteGame deletes the game and all of the attempts recorded against it.
@@ -273,17 +461,63 @@ func (s *PostgresStore) DeleteGame(ctx context.Context, adminToken string) error
 	if err != nil {
 		return err
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer s.rollback(ctx, tx)
 
-	_, err = tx.ExecContext(ctx, `DELETE FROM attempts WHERE game_token = (SELECT token FROM games WHERE admin_token = $1)`, adminToken)
+	err = deleteGame(ctx, tx, adminToken)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `DELETE FROM games WHERE admin_token = $1`, adminToken)
+	return tx.Commit()
+}
+
+func deleteGame(ctx context.Context, tx *sql.Tx, adminToken string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM attempts WHERE game_token = (SELECT token FROM games WHERE admin_token = $1)`, adminToken)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	res, err := tx.ExecContext(ctx, `DELETE FROM games WHERE admin_token = $1`, adminToken)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// PruneGames deletes games, and the attempts recorded against them, that
+// haven't been accessed since idleSince. Games that open later count as
+// accessed when they open, so they aren't pruned before anyone could play
+// them. It returns the number of games deleted.
+func (s *PostgresStore) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM attempts WHERE game_token IN (SELECT token FROM games WHERE GREATEST(accessed_at, opens_at) < $1)`, idleSince)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM games WHERE GREATEST(accessed_at, opens_at) < $1`, idleSince)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), tx.Commit()
 }
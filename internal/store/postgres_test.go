@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus/hooks/test"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/wording"
+	"github.com/connorkuehl/wording/migrations"
+)
+
+// newTestStore connects to the database named by WORDING_TEST_DB_DSN and
+// migrates it, or skips the test if it isn't set. The database is shared,
+// so tests use their own tokens rather than emptying it.
+func newTestStore(t *testing.T) *PostgresStore {
+	t.Helper()
+
+	dsn := os.Getenv("WORDING_TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("WORDING_TEST_DB_DSN is not set")
+	}
+
+	logger, _ := test.NewNullLogger()
+	s, err := NewPostgresStore(dsn, nil, logger)
+	assert.NilError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	all, err := LoadMigrations(migrations.FS)
+	assert.NilError(t, err)
+	_, err = s.MigrateUp(context.Background(), all)
+	assert.NilError(t, err)
+
+	return s
+}
+
+// testToken makes a token that no other test run uses.
+func testToken(prefix string) string {
+	return prefix + "-" + uuid.NewString()
+}
+
+// createTestGame creates a potato game with the given settings.
+func createTestGame(t *testing.T, s *PostgresStore, settings wording.GameSettings, creator string) *wording.Game {
+	t.Helper()
+
+	game, err := s.CreateGame(context.Background(), testToken("admin"), testToken("game"), "potato", 6,
+		wording.Difficulty{Score: 42}, settings.WithDefaults(), creator)
+	assert.NilError(t, err)
+	t.Cleanup(func() { s.DeleteGame(context.Background(), game.AdminToken) })
+
+	return game
+}
+
+func TestPruneGamesKeepsScheduledGames(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	idle := createTestGame(t, s, wording.GameSettings{}, "")
+	scheduled := createTestGame(t, s, wording.GameSettings{OpensAt: time.Now().Add(30 * 24 * time.Hour)}, "")
+
+	_, err := s.PruneGames(ctx, time.Now().Add(time.Hour))
+	assert.NilError(t, err)
+
+	_, err = s.Game(ctx, idle.AdminToken)
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Game(ctx, scheduled.AdminToken)
+	assert.NilError(t, err)
+}
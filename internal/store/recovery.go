@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// RotateAdminToken replaces a game's admin token, so the old one stops
+// working the moment the new one does. The answer is re-encrypted, since
+// it's bound to the admin token.
+func (s *PostgresStore) RotateAdminToken(ctx context.Context, adminToken, newAdminToken string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	var (
+		answer string
+		keyID  sql.NullString
+	)
+	err = tx.QueryRowContext(ctx, `
+	SELECT answer, answer_key_id FROM games WHERE admin_token = $1 FOR UPDATE
+	`, adminToken).Scan(&answer, &keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	answer, err = s.openAnswer(adminToken, keyID, answer)
+	if err != nil {
+		return err
+	}
+
+	keyID, sealed, err := s.sealAnswer(newAdminToken, answer)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE games
+	SET admin_token = $2, answer = $3, answer_key_id = $4, modified_at = NOW(), accessed_at = NOW()
+	WHERE admin_token = $1
+	`, adminToken, newAdminToken, sealed, keyID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetRecoveryEmail sets the hash of the address a game's new admin links
+// are sent to. An empty hash removes it.
+func (s *PostgresStore) SetRecoveryEmail(ctx context.Context, adminToken, emailHash string) error {
+	res, err := s.db.ExecContext(ctx, `
+	UPDATE games SET recovery_email_hash = $2, modified_at = NOW() WHERE admin_token = $1
+	`, adminToken, sql.NullString{String: emailHash, Valid: emailHash != ""})
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RecoverableGames fetches the tokens of the games that can be recovered
+// with an address, oldest first. Answers aren't included.
+func (s *PostgresStore) RecoverableGames(ctx context.Context, emailHash string) ([]wording.Game, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT admin_token, token, guess_limit FROM games WHERE recovery_email_hash = $1 ORDER BY created_at, admin_token
+	`, emailHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []wording.Game
+	for rows.Next() {
+		g := wording.Game{Recoverable: true}
+		err := rows.Scan(&g.AdminToken, &g.Token, &g.GuessLimit)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// CreateRecoveryToken saves a recovery token for the email address until
+// it expires. Expired tokens are cleared out at the same time.
+func (s *PostgresStore) CreateRecoveryToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(ctx, tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM recovery_tokens WHERE expires_at <= NOW()`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO recovery_tokens (token_hash, email_hash, expires_at) VALUES ($1, $2, $3)
+	`, tokenHash, emailHash, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryToken uses up a recovery token and returns the hash of the
+// address it was sent to. It returns ErrNotFound if the token doesn't
+// exist or has expired.
+func (s *PostgresStore) UseRecoveryToken(ctx context.Context, tokenHash string) (string, error) {
+	var emailHash string
+	err := s.db.QueryRowContext(ctx, `
+	DELETE FROM recovery_tokens WHERE token_hash = $1 AND expires_at > NOW() RETURNING email_hash
+	`, tokenHash).Scan(&emailHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrNotFound
+	}
+	return emailHash, err
+}
@@ -0,0 +1,5 @@
+// Package tracing instruments the application with OpenTelemetry.
+//
+// Like package metrics, the service and store are wrapped in decorators
+// so that the business logic only has to pass its context along.
+package tracing
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler starts a span for every request, continuing the caller's trace
+// if the request carries W3C trace context headers.
+func Handler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.request")
+}
+
+// RouteName is chi middleware that names the request's span after the
+// matched route pattern (e.g., "POST /game/{token}"), which is only known
+// once chi has routed the request.
+func RouteName(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.RoutePattern() == "" {
+			return
+		}
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + rctx.RoutePattern())
+		span.SetAttributes(semconv.HTTPRouteKey.String(rctx.RoutePattern()))
+	})
+}
@@ -0,0 +1,282 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+type tracedService struct {
+	svc service.Service
+}
+
+// Service decorates svc so that every call gets its own span.
+func Service(svc service.Service) service.Service {
+	return &tracedService{svc: svc}
+}
+
+func (t *tracedService) CreateGame(ctx context.Context, answer string, guessLimit int, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	ctx, span := start(ctx, "service.CreateGame", attribute.Int("wording.guess_limit", guessLimit))
+	v, err := t.svc.CreateGame(ctx, answer, guessLimit, settings, creator)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) DeleteGame(ctx context.Context, adminToken string) error {
+	ctx, span := start(ctx, "service.DeleteGame")
+	err := t.svc.DeleteGame(ctx, adminToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
+	ctx, span := start(ctx, "service.Game")
+	v, err := t.svc.Game(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
+	ctx, span := start(ctx, "service.GameByToken", gameTokenAttr(token))
+	v, err := t.svc.GameByToken(ctx, token)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) GameState(ctx context.Context, gameToken, playerToken string) (*wording.GameState, error) {
+	ctx, span := start(ctx, "service.GameState", gameTokenAttr(gameToken))
+	v, err := t.svc.GameState(ctx, gameToken, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	ctx, span := start(ctx, "service.GameStats")
+	v, err := t.svc.GameStats(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error) {
+	ctx, span := start(ctx, "service.Plays", gameTokenAttr(gameToken))
+	v, err := t.svc.Plays(ctx, gameToken, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) Stats(ctx context.Context) (wording.Stats, error) {
+	ctx, span := start(ctx, "service.Stats")
+	v, err := t.svc.Stats(ctx)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) SubmitGuess(ctx context.Context, gameToken, playerToken, guess string) error {
+	ctx, span := start(ctx, "service.SubmitGuess", gameTokenAttr(gameToken))
+	err := t.svc.SubmitGuess(ctx, gameToken, playerToken, guess)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) NewPlayerToken(ctx context.Context) string {
+	ctx, span := start(ctx, "service.NewPlayerToken")
+	defer span.End()
+	return t.svc.NewPlayerToken(ctx)
+}
+
+func (t *tracedService) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	ctx, span := start(ctx, "service.PruneGames")
+	v, err := t.svc.PruneGames(ctx, idleSince)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) ExportGame(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	ctx, span := start(ctx, "service.ExportGame")
+	v, err := t.svc.ExportGame(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	ctx, span := start(ctx, "service.ExportGames")
+	err := t.svc.ExportGames(ctx, fn)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) ImportGame(ctx context.Context, record *wording.GameRecord, freshTokens bool) (*wording.GameRecord, error) {
+	ctx, span := start(ctx, "service.ImportGame", gameTokenAttr(record.Token))
+	v, err := t.svc.ImportGame(ctx, record, freshTokens)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) SetStats(ctx context.Context, stats wording.Stats) error {
+	ctx, span := start(ctx, "service.SetStats")
+	err := t.svc.SetStats(ctx, stats)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) DailyStats(ctx context.Context, days int) ([]wording.DailyStats, error) {
+	ctx, span := start(ctx, "service.DailyStats")
+	v, err := t.svc.DailyStats(ctx, days)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) GameDailyStats(ctx context.Context, adminToken string, days int) ([]wording.DailyStats, error) {
+	ctx, span := start(ctx, "service.GameDailyStats")
+	v, err := t.svc.GameDailyStats(ctx, adminToken, days)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) PlayerHistory(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	ctx, span := start(ctx, "service.PlayerHistory")
+	v, err := t.svc.PlayerHistory(ctx, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) PlayerStats(ctx context.Context, playerToken string) (wording.PlayerStats, error) {
+	ctx, span := start(ctx, "service.PlayerStats")
+	v, err := t.svc.PlayerStats(ctx, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) ClearPlayerHistory(ctx context.Context, playerToken string) (int, error) {
+	ctx, span := start(ctx, "service.ClearPlayerHistory")
+	v, err := t.svc.ClearPlayerHistory(ctx, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) CreatedGames(ctx context.Context, creator string, page int) (*wording.CreatedGames, error) {
+	ctx, span := start(ctx, "service.CreatedGames", attribute.Int("wording.page", page))
+	v, err := t.svc.CreatedGames(ctx, creator, page)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) MailEnabled() bool {
+	return t.svc.MailEnabled()
+}
+
+func (t *tracedService) RequestLogin(ctx context.Context, email string) error {
+	ctx, span := start(ctx, "service.RequestLogin")
+	err := t.svc.RequestLogin(ctx, email)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) Login(ctx context.Context, loginToken, playerToken string) (*wording.Session, error) {
+	ctx, span := start(ctx, "service.Login")
+	v, err := t.svc.Login(ctx, loginToken, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) Session(ctx context.Context, sessionToken string) (*wording.Account, error) {
+	ctx, span := start(ctx, "service.Session")
+	v, err := t.svc.Session(ctx, sessionToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) Logout(ctx context.Context, sessionToken string) error {
+	ctx, span := start(ctx, "service.Logout")
+	err := t.svc.Logout(ctx, sessionToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	ctx, span := start(ctx, "service.DeleteCreatedGames", attribute.Int("wording.games", len(adminTokens)))
+	v, err := t.svc.DeleteCreatedGames(ctx, creator, adminTokens)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) RotateAdminToken(ctx context.Context, adminToken string) (string, error) {
+	ctx, span := start(ctx, "service.RotateAdminToken")
+	v, err := t.svc.RotateAdminToken(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) SetRecoveryEmail(ctx context.Context, adminToken, email string) error {
+	ctx, span := start(ctx, "service.SetRecoveryEmail")
+	err := t.svc.SetRecoveryEmail(ctx, adminToken, email)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) RequestRecovery(ctx context.Context, email string) error {
+	ctx, span := start(ctx, "service.RequestRecovery")
+	err := t.svc.RequestRecovery(ctx, email)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) RecoverGames(ctx context.Context, recoveryToken string) ([]wording.Game, error) {
+	ctx, span := start(ctx, "service.RecoverGames")
+	v, err := t.svc.RecoverGames(ctx, recoveryToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) SearchGames(ctx context.Context, search wording.GameSearch, page int) (*wording.ListedGames, error) {
+	ctx, span := start(ctx, "service.SearchGames", attribute.Int("wording.page", page))
+	v, err := t.svc.SearchGames(ctx, search, page)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	ctx, span := start(ctx, "service.InspectGame")
+	v, err := t.svc.InspectGame(ctx, operator, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	ctx, span := start(ctx, "service.SetGameDisabled", attribute.Bool("wording.disabled", disabled))
+	err := t.svc.SetGameDisabled(ctx, operator, adminToken, disabled)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	ctx, span := start(ctx, "service.OperatorDeleteGame")
+	err := t.svc.OperatorDeleteGame(ctx, operator, adminToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedService) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	ctx, span := start(ctx, "service.AuditLog")
+	v, err := t.svc.AuditLog(ctx, limit)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) Leaderboard(ctx context.Context, gameToken string) ([]wording.Standing, error) {
+	ctx, span := start(ctx, "service.Leaderboard", gameTokenAttr(gameToken))
+	v, err := t.svc.Leaderboard(ctx, gameToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedService) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	ctx, span := start(ctx, "service.UpdateGameSettings")
+	err := t.svc.UpdateGameSettings(ctx, adminToken, settings)
+	end(span, err)
+	return err
+}
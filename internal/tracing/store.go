@@ -0,0 +1,263 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+type tracedStore struct {
+	store service.Store
+}
+
+// Store decorates a store so that every query gets its own span.
+func Store(s service.Store) service.Store {
+	return &tracedStore{store: s}
+}
+
+func (t *tracedStore) CreateGame(ctx context.Context, adminToken, token, answer string, guessLimit int, difficulty wording.Difficulty, settings wording.GameSettings, creator string) (*wording.Game, error) {
+	ctx, span := start(ctx, "store.CreateGame", gameTokenAttr(token))
+	v, err := t.store.CreateGame(ctx, adminToken, token, answer, guessLimit, difficulty, settings, creator)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) Game(ctx context.Context, adminToken string) (*wording.Game, error) {
+	ctx, span := start(ctx, "store.Game")
+	v, err := t.store.Game(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) GameByToken(ctx context.Context, token string) (*wording.Game, error) {
+	ctx, span := start(ctx, "store.GameByToken", gameTokenAttr(token))
+	v, err := t.store.GameByToken(ctx, token)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) Plays(ctx context.Context, gameToken, playerToken string) (*wording.Plays, error) {
+	ctx, span := start(ctx, "store.Plays", gameTokenAttr(gameToken))
+	v, err := t.store.Plays(ctx, gameToken, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) PutPlays(ctx context.Context, gameToken, playerToken string, plays *wording.Plays, outcome wording.PlayOutcome) error {
+	ctx, span := start(ctx, "store.PutPlays", gameTokenAttr(gameToken))
+	err := t.store.PutPlays(ctx, gameToken, playerToken, plays, outcome)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) IncrementStats(ctx context.Context, stats wording.IncrementStats) error {
+	ctx, span := start(ctx, "store.IncrementStats")
+	err := t.store.IncrementStats(ctx, stats)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) GameStats(ctx context.Context, adminToken string) (wording.GameStats, error) {
+	ctx, span := start(ctx, "store.GameStats")
+	v, err := t.store.GameStats(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) Stats(ctx context.Context) (wording.Stats, error) {
+	ctx, span := start(ctx, "store.Stats")
+	v, err := t.store.Stats(ctx)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) DeleteGame(ctx context.Context, adminToken string) error {
+	ctx, span := start(ctx, "store.DeleteGame")
+	err := t.store.DeleteGame(ctx, adminToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) PruneGames(ctx context.Context, idleSince time.Time) (int, error) {
+	ctx, span := start(ctx, "store.PruneGames")
+	v, err := t.store.PruneGames(ctx, idleSince)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) ExportGames(ctx context.Context, fn func(*wording.GameRecord) error) error {
+	ctx, span := start(ctx, "store.ExportGames")
+	err := t.store.ExportGames(ctx, fn)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) GameRecord(ctx context.Context, adminToken string) (*wording.GameRecord, error) {
+	ctx, span := start(ctx, "store.GameRecord")
+	v, err := t.store.GameRecord(ctx, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) ImportGame(ctx context.Context, record *wording.GameRecord) error {
+	ctx, span := start(ctx, "store.ImportGame", gameTokenAttr(record.Token))
+	err := t.store.ImportGame(ctx, record)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) SetStats(ctx context.Context, stats wording.Stats) error {
+	ctx, span := start(ctx, "store.SetStats")
+	err := t.store.SetStats(ctx, stats)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) DailyStats(ctx context.Context, since time.Time) ([]wording.DailyStats, error) {
+	ctx, span := start(ctx, "store.DailyStats")
+	v, err := t.store.DailyStats(ctx, since)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) GameDailyStats(ctx context.Context, adminToken string, since time.Time) ([]wording.DailyStats, error) {
+	ctx, span := start(ctx, "store.GameDailyStats")
+	v, err := t.store.GameDailyStats(ctx, adminToken, since)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) PlayerGames(ctx context.Context, playerToken string) ([]wording.PlayerGame, error) {
+	ctx, span := start(ctx, "store.PlayerGames")
+	v, err := t.store.PlayerGames(ctx, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) DeletePlayerGames(ctx context.Context, playerToken string) (int, error) {
+	ctx, span := start(ctx, "store.DeletePlayerGames")
+	v, err := t.store.DeletePlayerGames(ctx, playerToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) CreateLoginToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	ctx, span := start(ctx, "store.CreateLoginToken")
+	err := t.store.CreateLoginToken(ctx, tokenHash, emailHash, expiresAt)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) StartSession(ctx context.Context, loginTokenHash, playerToken, newPlayerToken, sessionHash string, expiresAt time.Time) (*wording.Account, error) {
+	ctx, span := start(ctx, "store.StartSession")
+	v, err := t.store.StartSession(ctx, loginTokenHash, playerToken, newPlayerToken, sessionHash, expiresAt)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) AccountSession(ctx context.Context, sessionHash string) (*wording.Account, error) {
+	ctx, span := start(ctx, "store.AccountSession")
+	v, err := t.store.AccountSession(ctx, sessionHash)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) DeleteSession(ctx context.Context, sessionHash string) error {
+	ctx, span := start(ctx, "store.DeleteSession")
+	err := t.store.DeleteSession(ctx, sessionHash)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) CreatedGames(ctx context.Context, creator string, limit, offset int) ([]wording.CreatedGame, error) {
+	ctx, span := start(ctx, "store.CreatedGames")
+	v, err := t.store.CreatedGames(ctx, creator, limit, offset)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) DeleteCreatedGames(ctx context.Context, creator string, adminTokens []string) (int, error) {
+	ctx, span := start(ctx, "store.DeleteCreatedGames")
+	v, err := t.store.DeleteCreatedGames(ctx, creator, adminTokens)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) RotateAdminToken(ctx context.Context, adminToken, newAdminToken string) error {
+	ctx, span := start(ctx, "store.RotateAdminToken")
+	err := t.store.RotateAdminToken(ctx, adminToken, newAdminToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) SetRecoveryEmail(ctx context.Context, adminToken, emailHash string) error {
+	ctx, span := start(ctx, "store.SetRecoveryEmail")
+	err := t.store.SetRecoveryEmail(ctx, adminToken, emailHash)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) RecoverableGames(ctx context.Context, emailHash string) ([]wording.Game, error) {
+	ctx, span := start(ctx, "store.RecoverableGames")
+	v, err := t.store.RecoverableGames(ctx, emailHash)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) CreateRecoveryToken(ctx context.Context, tokenHash, emailHash string, expiresAt time.Time) error {
+	ctx, span := start(ctx, "store.CreateRecoveryToken")
+	err := t.store.CreateRecoveryToken(ctx, tokenHash, emailHash, expiresAt)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) UseRecoveryToken(ctx context.Context, tokenHash string) (string, error) {
+	ctx, span := start(ctx, "store.UseRecoveryToken")
+	v, err := t.store.UseRecoveryToken(ctx, tokenHash)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) SearchGames(ctx context.Context, search wording.GameSearch, limit, offset int) ([]wording.ListedGame, error) {
+	ctx, span := start(ctx, "store.SearchGames")
+	v, err := t.store.SearchGames(ctx, search, limit, offset)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) InspectGame(ctx context.Context, operator, adminToken string) (*wording.GameRecord, error) {
+	ctx, span := start(ctx, "store.InspectGame")
+	v, err := t.store.InspectGame(ctx, operator, adminToken)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) SetGameDisabled(ctx context.Context, operator, adminToken string, disabled bool) error {
+	ctx, span := start(ctx, "store.SetGameDisabled")
+	err := t.store.SetGameDisabled(ctx, operator, adminToken, disabled)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) OperatorDeleteGame(ctx context.Context, operator, adminToken string) error {
+	ctx, span := start(ctx, "store.OperatorDeleteGame")
+	err := t.store.OperatorDeleteGame(ctx, operator, adminToken)
+	end(span, err)
+	return err
+}
+
+func (t *tracedStore) AuditLog(ctx context.Context, limit int) ([]wording.AuditEntry, error) {
+	ctx, span := start(ctx, "store.AuditLog")
+	v, err := t.store.AuditLog(ctx, limit)
+	end(span, err)
+	return v, err
+}
+
+func (t *tracedStore) UpdateGameSettings(ctx context.Context, adminToken string, settings wording.GameSettings) error {
+	ctx, span := start(ctx, "store.UpdateGameSettings")
+	err := t.store.UpdateGameSettings(ctx, adminToken, settings)
+	end(span, err)
+	return err
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	return recorder
+}
+
+// fakeStore implements just enough of service.Store for the test.
+type fakeStore struct {
+	service.Store
+}
+
+func (fakeStore) GameByToken(context.Context, string) (*wording.Game, error) {
+	return nil, service.ErrNotFound
+}
+
+func TestStoreSpans(t *testing.T) {
+	recorder := newRecorder(t)
+
+	ctx, parent := tracer().Start(context.Background(), "parent")
+	_, err := Store(fakeStore{}).GameByToken(ctx, "hungry-hippo")
+	parent.End()
+	assert.Assert(t, errors.Is(err, service.ErrNotFound))
+
+	spans := recorder.Ended()
+	assert.Equal(t, 2, len(spans))
+
+	span := spans[0]
+	assert.Equal(t, "store.GameByToken", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, 1, len(span.Attributes()))
+	assert.Equal(t, attribute.Key("wording.game_token"), span.Attributes()[0].Key)
+	assert.Equal(t, "hungry-hippo", span.Attributes()[0].Value.AsString())
+}
+
+func TestRouteName(t *testing.T) {
+	recorder := newRecorder(t)
+
+	router := chi.NewRouter()
+	router.Use(RouteName)
+	router.Post("/game/{token}", func(w http.ResponseWriter, r *http.Request) {})
+
+	Handler(router).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/game/hungry-hippo", nil))
+
+	spans := recorder.Ended()
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, "POST /game/{token}", spans[0].Name())
+}
@@ -0,0 +1,2 @@
+// Package tui is a terminal client for playing games through the API.
+package tui
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// Game is a game being played through the API.
+type Game struct {
+	Client   *api.Client
+	Token    string
+	Renderer Renderer
+	// OnPlayerToken, if set, is called whenever the server issues a player
+	// token so it can be saved before the player walks away.
+	OnPlayerToken func(token string) error
+}
+
+// Play shows the game and reads guesses, one per line, until the game is
+// over, runs out of guesses, or ctx is cancelled.
+func (g *Game) Play(ctx context.Context, in io.Reader, out io.Writer) error {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	game, err := g.fetch(ctx, func() (*api.Game, error) {
+		return g.Client.Game(ctx, g.Token)
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		fmt.Fprintln(out)
+		g.Renderer.Grid(out, game)
+		fmt.Fprintln(out)
+		g.Renderer.Keyboard(out, game.State)
+		fmt.Fprintln(out)
+
+		if !game.State.CanContinue {
+			g.printResult(out, game)
+			return nil
+		}
+
+		fmt.Fprintf(out, "Guess %d of %d (%d letters): ", len(game.State.Attempts)+1, game.GuessLimit, game.Length)
+
+		var guess string
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return ctx.Err()
+		case line, ok := <-lines:
+			if !ok {
+				fmt.Fprintln(out)
+				return nil
+			}
+			guess = strings.TrimSpace(line)
+		}
+
+		if guess == "" {
+			continue
+		}
+
+		next, err := g.fetch(ctx, func() (*api.Game, error) {
+			return g.Client.Guess(ctx, g.Token, strings.ToLower(guess))
+		})
+		switch {
+		case api.IsStatus(err, http.StatusBadRequest):
+			printViolations(out, err)
+			continue
+		case api.IsStatus(err, http.StatusConflict):
+			// Finished elsewhere, e.g., in a browser with the same token.
+			next, err = g.fetch(ctx, func() (*api.Game, error) {
+				return g.Client.Game(ctx, g.Token)
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		game = next
+	}
+}
+
+// fetch calls the API and saves the player token if the server issued a new
+// one.
+func (g *Game) fetch(ctx context.Context, call func() (*api.Game, error)) (*api.Game, error) {
+	before := g.Client.PlayerToken()
+
+	game, err := call()
+
+	if after := g.Client.PlayerToken(); after != before && g.OnPlayerToken != nil {
+		if saveErr := g.OnPlayerToken(after); saveErr != nil {
+			return nil, fmt.Errorf("saving player token: %w", saveErr)
+		}
+	}
+
+	return game, err
+}
+
+func (g *Game) printResult(out io.Writer, game *api.Game) {
+	var schedule wording.GameSettings
+	if game.OpensAt != nil {
+		schedule.OpensAt = *game.OpensAt
+	}
+	if game.ClosesAt != nil {
+		schedule.ClosesAt = *game.ClosesAt
+	}
+
+	// A game that can't be played isn't one the player won or lost, unless
+	// they finished it before it closed.
+	now := time.Now()
+	finished := game.State.Victorious || game.State.GameOver
+	switch {
+	case !schedule.Opened(now):
+		fmt.Fprintf(out, "This game opens at %s.\n", schedule.OpensAt.Local().Format(time.RFC1123))
+		return
+	case schedule.Closed(now) && !finished:
+		fmt.Fprintln(out, "This game has closed.")
+		if game.State.Answer != "" {
+			fmt.Fprintf(out, "The answer was %s.\n", strings.ToUpper(game.State.Answer))
+		}
+		return
+	}
+
+	if game.State.Victorious {
+		fmt.Fprintln(out, "You are victorious!")
+	} else {
+		fmt.Fprintln(out, "You lost :(")
+		if game.State.Answer != "" {
+			fmt.Fprintf(out, "The answer was %s.\n", strings.ToUpper(game.State.Answer))
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, game.State.GameState().ShareText("Wording "+game.Token, game.GuessLimit))
+}
+
+func printViolations(out io.Writer, err error) {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || len(apiErr.Violations) == 0 {
+		fmt.Fprintln(out, err)
+		return
+	}
+
+	for _, msgs := range apiErr.Violations {
+		for _, msg := range msgs {
+			fmt.Fprintln(out, msg)
+		}
+	}
+}
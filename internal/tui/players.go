@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Players remembers the signed player token issued by each server, so
+// progress resumes across runs.
+type Players struct {
+	path string
+}
+
+// NewPlayers keeps tokens in the file at path.
+func NewPlayers(path string) *Players {
+	return &Players{path: path}
+}
+
+// DefaultPlayersPath is where tokens are kept unless told otherwise.
+func DefaultPlayersPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "wording", "players.json"), nil
+}
+
+// Token returns the token saved for server, if any.
+func (p *Players) Token(server string) (string, error) {
+	tokens, err := p.load()
+	if err != nil {
+		return "", err
+	}
+	return tokens[server], nil
+}
+
+// SaveToken remembers token for server.
+func (p *Players) SaveToken(server, token string) error {
+	tokens, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	if tokens[server] == token {
+		return nil
+	}
+	tokens[server] = token
+
+	b, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(p.path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	// The token is all it takes to play as someone, so keep it private.
+	return os.WriteFile(p.path, b, 0o600)
+}
+
+func (p *Players) load() (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	b, err := os.ReadFile(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/connorkuehl/wording/internal/api"
+)
+
+type letterStatus int
+
+const (
+	unknown letterStatus = iota
+	absent
+	partial
+	correct
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiCorrect = "\x1b[1;30;42m"
+	ansiPartial = "\x1b[1;30;43m"
+	ansiAbsent  = "\x1b[2;37;100m"
+)
+
+var keyboardRows = []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+
+// Renderer draws games as text. Without color, correct letters are shown
+// in [brackets], partial ones in (parentheses), and letters known to be
+// absent are hidden from the keyboard.
+type Renderer struct {
+	Color bool
+}
+
+// Grid draws every attempt, followed by an empty row for each remaining
+// guess.
+func (r Renderer) Grid(w io.Writer, game *api.Game) {
+	for _, attempt := range game.State.Attempts {
+		var b strings.Builder
+		for _, ch := range attempt {
+			b.WriteString(r.cell(strings.ToUpper(ch.Value), statusOf(ch)))
+		}
+		fmt.Fprintln(w, b.String())
+	}
+
+	empty := strings.Repeat(r.cell("_", unknown), game.Length)
+	for i := len(game.State.Attempts); i < game.GuessLimit; i++ {
+		fmt.Fprintln(w, empty)
+	}
+}
+
+// Keyboard draws the alphabet colored by what the player knows about each
+// letter so far.
+func (r Renderer) Keyboard(w io.Writer, state api.GameState) {
+	known := make(map[string]letterStatus)
+	for _, attempt := range state.Attempts {
+		for _, ch := range attempt {
+			letter := strings.ToUpper(ch.Value)
+			if s := statusOf(ch); s > known[letter] {
+				known[letter] = s
+			}
+		}
+	}
+
+	for i, row := range keyboardRows {
+		var b strings.Builder
+		b.WriteString(strings.Repeat(" ", i))
+		for _, letter := range row {
+			b.WriteString(r.cell(string(letter), known[string(letter)]))
+		}
+		fmt.Fprintln(w, b.String())
+	}
+}
+
+func (r Renderer) cell(letter string, status letterStatus) string {
+	if r.Color {
+		switch status {
+		case correct:
+			return ansiCorrect + " " + letter + " " + ansiReset
+		case partial:
+			return ansiPartial + " " + letter + " " + ansiReset
+		case absent:
+			return ansiAbsent + " " + letter + " " + ansiReset
+		}
+		return " " + letter + " "
+	}
+
+	switch status {
+	case correct:
+		return "[" + letter + "]"
+	case partial:
+		return "(" + letter + ")"
+	case absent:
+		return "   "
+	}
+	return " " + letter + " "
+}
+
+func statusOf(ch api.Character) letterStatus {
+	switch {
+	case ch.Correct:
+		return correct
+	case ch.Partial:
+		return partial
+	default:
+		return absent
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+func TestRendererGrid(t *testing.T) {
+	plays := wording.Plays{Attempts: []string{"tomato"}}
+	game := &api.Game{
+		Length:     6,
+		GuessLimit: 2,
+		State:      api.FromGameState(plays.Evaluate("potato", 2)),
+	}
+
+	var b strings.Builder
+	Renderer{}.Grid(&b, game)
+
+	assert.Equal(t, "(T)[O]   [A][T][O]\n _  _  _  _  _  _ \n", b.String())
+}
+
+func TestRendererKeyboard(t *testing.T) {
+	plays := wording.Plays{Attempts: []string{"tomato"}}
+	state := api.FromGameState(plays.Evaluate("potato", 6))
+
+	var b strings.Builder
+	Renderer{}.Keyboard(&b, state)
+
+	assert.Equal(t, ""+
+		" Q  W  E  R [T] Y  U  I [O] P \n"+
+		" [A] S  D  F  G  H  J  K  L \n"+
+		"   Z  X  C  V  B  N    \n", b.String())
+}
+
+func TestPlayersRoundTrip(t *testing.T) {
+	p := NewPlayers(filepath.Join(t.TempDir(), "wording", "players.json"))
+
+	got, err := p.Token("https://wording.example")
+	assert.NilError(t, err)
+	assert.Equal(t, "", got)
+
+	assert.NilError(t, p.SaveToken("https://wording.example", "signed-token"))
+
+	got, err = p.Token("https://wording.example")
+	assert.NilError(t, err)
+	assert.Equal(t, "signed-token", got)
+}
+
+func TestPlay(t *testing.T) {
+	plays := &wording.Plays{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var req api.GuessRequest
+			assert.NilError(t, json.NewDecoder(r.Body).Decode(&req))
+			plays.Attempts = append(plays.Attempts, req.Guess)
+		}
+
+		if r.Header.Get(api.PlayerHeader) == "" {
+			w.Header().Set(api.PlayerHeader, "issued-token")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.Game{
+			Token:      "hungry-hippo",
+			Length:     6,
+			GuessLimit: 6,
+			State:      api.FromGameState(plays.Evaluate("potato", 6)),
+		})
+	})
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	var saved string
+	g := &Game{
+		Client: api.NewClient(ts.URL, ""),
+		Token:  "hungry-hippo",
+		OnPlayerToken: func(token string) error {
+			saved = token
+			return nil
+		},
+	}
+
+	var out strings.Builder
+	err := g.Play(context.Background(), strings.NewReader("tomato\n\nPOTATO\n"), &out)
+	assert.NilError(t, err)
+
+	assert.Equal(t, "issued-token", saved)
+	assert.DeepEqual(t, []string{"tomato", "potato"}, plays.Attempts)
+	assert.Assert(t, strings.Contains(out.String(), "You are victorious!"))
+	assert.Assert(t, strings.HasSuffix(out.String(), "Wording hungry-hippo 2/6\n\n🟨🟩⬛🟩🟩🟩\n🟩🟩🟩🟩🟩🟩\n"))
+}
+
+func TestPlayUnplayable(t *testing.T) {
+	opensAt := time.Now().Add(24 * time.Hour).UTC()
+	closedAt := time.Now().Add(-24 * time.Hour).UTC()
+
+	tests := []struct {
+		name string
+		game api.Game
+		want string
+	}{
+		{
+			name: "not open yet",
+			game: api.Game{OpensAt: &opensAt},
+			want: "This game opens at " + opensAt.Local().Format(time.RFC1123) + ".\n",
+		},
+		{
+			name: "closed",
+			game: api.Game{ClosesAt: &closedAt, State: api.GameState{Answer: "potato"}},
+			want: "This game has closed.\nThe answer was POTATO.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.game.Token = "hungry-hippo"
+			tt.game.Length = 6
+			tt.game.GuessLimit = 6
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.game)
+			}))
+			defer ts.Close()
+
+			g := &Game{Client: api.NewClient(ts.URL, "player"), Token: "hungry-hippo"}
+
+			var out strings.Builder
+			err := g.Play(context.Background(), strings.NewReader(""), &out)
+			assert.NilError(t, err)
+
+			assert.Assert(t, strings.HasSuffix(out.String(), tt.want), out.String())
+			assert.Assert(t, !strings.Contains(out.String(), "You lost"), out.String())
+			assert.Assert(t, !strings.Contains(out.String(), "Wording hungry-hippo"), out.String())
+		})
+	}
+}
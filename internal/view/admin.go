@@ -0,0 +1,45 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+//go:embed admin.tmpl.html
+var adminHTML string
+
+var adminTmpl = template.Must(template.New("admin").Parse(adminHTML))
+
+// Admin is the front page of the operator console.
+type Admin struct {
+	Operator string
+	Stats    wording.Stats
+	// Days are the recent daily stats for every game, oldest first.
+	Days []Day
+	// Errors and Audit are newest first.
+	Errors []LoggedError
+	Audit  []AuditEntry
+}
+
+// LoggedError is an error logged by this replica.
+type LoggedError struct {
+	Time    string
+	Message string
+	Fields  string
+}
+
+// AuditEntry is something an operator did.
+type AuditEntry struct {
+	Time      string
+	Operator  string
+	Action    string
+	GameToken string
+}
+
+// RenderTo renders the operator console.
+func (a Admin) RenderTo(w io.Writer) error {
+	return adminTmpl.Execute(w, a)
+}
@@ -0,0 +1,41 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed admin_game.tmpl.html
+var adminGameHTML string
+
+var adminGameTmpl = template.Must(template.New("admin_game").Parse(adminGameHTML))
+
+// AdminGame shows an operator everything about a game.
+type AdminGame struct {
+	CSRFToken  string
+	BaseURL    string
+	AdminToken string
+	Token      string
+	Answer     string
+	GuessLimit int
+	Created    string
+	Disabled   bool
+	// Difficulty is nil for games created before answers were rated.
+	Difficulty *Difficulty
+	Players    []AdminPlayer
+}
+
+// AdminPlayer is one player's attempts at a game.
+type AdminPlayer struct {
+	// Player is a hash of the player's token, as it appears in the logs.
+	Player   string
+	Started  string
+	Attempts []string
+	Result   string
+}
+
+// RenderTo renders the game.
+func (a AdminGame) RenderTo(w io.Writer) error {
+	return adminGameTmpl.Execute(w, a)
+}
@@ -0,0 +1,46 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed admin_games.tmpl.html
+var adminGamesHTML string
+
+var adminGamesTmpl = template.Must(template.New("admin_games").Parse(adminGamesHTML))
+
+// AdminGames lets operators search for games.
+type AdminGames struct {
+	Operator string
+	// Token, From, To and ByActivity are the search as entered.
+	Token      string
+	From       string
+	To         string
+	ByActivity bool
+	Games      []ListedGame
+	Page       int
+	// PrevURL and NextURL link to the same search's other pages, and are
+	// empty if there isn't one.
+	PrevURL string
+	NextURL string
+	// Deleted is set right after a game was deleted.
+	Deleted bool
+}
+
+// ListedGame is a game found by a search.
+type ListedGame struct {
+	AdminToken string
+	Token      string
+	Created    string
+	Accessed   string
+	GuessLimit int
+	Players    int
+	Disabled   bool
+}
+
+// RenderTo renders the search page.
+func (a AdminGames) RenderTo(w io.Writer) error {
+	return adminGamesTmpl.Execute(w, a)
+}
@@ -15,7 +15,8 @@ var homeTmpl = template.Must(template.New("home").Parse(homePage))
 
 // Home is the homepage/game creation screen.
 type Home struct {
-	Stats wording.Stats
+	CSRFToken string
+	Stats     wording.Stats
 }
 
 // RenderTo renders the home page.
@@ -0,0 +1,27 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed login.tmpl.html
+var loginHTML string
+
+var loginTmpl = template.Must(template.New("login").Parse(loginHTML))
+
+// Login asks for an email address to send a login link to, or confirms
+// logging in with one.
+type Login struct {
+	CSRFToken string
+	// Token is the login link's token when the player followed one.
+	Token string
+	// Sent is set once a login link has been emailed.
+	Sent bool
+}
+
+// RenderTo renders the login page.
+func (l Login) RenderTo(w io.Writer) error {
+	return loginTmpl.Execute(w, l)
+}
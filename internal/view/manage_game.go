@@ -13,6 +13,7 @@ var manageGameTmpl = template.Must(template.New("manage-game").Parse(manageGameH
 
 // ManageGame is the game management screen.
 type ManageGame struct {
+	CSRFToken      string
 	BaseURL        string
 	AdminToken     string
 	Token          string
@@ -20,6 +21,86 @@ type ManageGame struct {
 	GuessesAllowed int
 	GuessesMade    int
 	CorrectGuesses int
+	// PlayersStarted, PlayersFinished and Losses count players rather
+	// than guesses.
+	PlayersStarted  int
+	PlayersFinished int
+	Losses          int
+	// Distribution has a bar for winning in each number of guesses, then
+	// one for losing.
+	Distribution      []Bar
+	FirstGuesses      []Bar
+	WrongFinalGuesses []Bar
+	// Difficulty is nil for games created before answers were rated.
+	Difficulty *Difficulty
+	// Days are the game's recent daily stats, oldest first.
+	Days []Day
+	// Rotated is set right after the admin link was replaced.
+	Rotated bool
+	// Recoverable is set if new admin links can be emailed to the
+	// creator, which is only offered if MailEnabled.
+	Recoverable bool
+	MailEnabled bool
+	// Disabled is set if an operator has stopped the game from being
+	// played.
+	Disabled bool
+	// OpensAt and ClosesAt are the game's schedule as datetime-local
+	// values in UTC, and empty if that end is open.
+	OpensAt  string
+	ClosesAt string
+	Closed   bool
+	// Reveal is when players who didn't win are shown the answer.
+	Reveal string
+	// AnswerRevealed is set if Reveal lets players who didn't win see the
+	// answer by now.
+	AnswerRevealed bool
+}
+
+// Bar is one bar of a bar chart.
+type Bar struct {
+	Label string
+	Count int
+	// Percent is the bar's length relative to the longest bar in its chart.
+	Percent int
+}
+
+// Bars builds a chart's bars, scaling them to the largest count.
+func Bars(labels []string, counts []int) []Bar {
+	largest := 0
+	for _, n := range counts {
+		if n > largest {
+			largest = n
+		}
+	}
+
+	bars := make([]Bar, len(counts))
+	for i, n := range counts {
+		bars[i] = Bar{Label: labels[i], Count: n}
+		if largest > 0 {
+			bars[i].Percent = n * 100 / largest
+		}
+	}
+
+	return bars
+}
+
+// Day is a single day's stats.
+type Day struct {
+	Date string
+	// GamesCreated is only counted for every game together.
+	GamesCreated   int
+	PlayersStarted int
+	GuessesMade    int
+	GamesWon       int
+}
+
+// Difficulty is how hard a game's answer was rated.
+type Difficulty struct {
+	Score           int
+	Label           string
+	SolverGuesses   int
+	ExpectedGuesses int
+	LimitTooLow     bool
 }
 
 // RenderTo renders the management page.
@@ -0,0 +1,43 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed me.tmpl.html
+var meHTML string
+
+var meTmpl = template.Must(template.New("me").Parse(meHTML))
+
+// Me is a player's own stats and history.
+type Me struct {
+	CSRFToken     string
+	GamesPlayed   int
+	GamesWon      int
+	WinRate       int
+	CurrentStreak int
+	MaxStreak     int
+	// Distribution has a bar for winning in each number of guesses.
+	Distribution []Bar
+	// Games are the player's games, most recent first.
+	Games []PlayedGame
+	// Cleared is how many games were just forgotten, if the player
+	// cleared their history.
+	Cleared *int
+}
+
+// PlayedGame is a game in a player's history.
+type PlayedGame struct {
+	Token   string
+	Date    string
+	Guesses int
+	Limit   int
+	Result  string
+}
+
+// RenderTo renders the player's page.
+func (m Me) RenderTo(w io.Writer) error {
+	return meTmpl.Execute(w, m)
+}
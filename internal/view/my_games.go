@@ -0,0 +1,46 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed my_games.tmpl.html
+var myGamesHTML string
+
+var myGamesTmpl = template.Must(template.New("my_games").Parse(myGamesHTML))
+
+// MyGames lists the games a player created, so they don't have to keep
+// the admin links.
+type MyGames struct {
+	CSRFToken    string
+	BaseURL      string
+	LoggedIn     bool
+	LoginEnabled bool
+	// Games are newest first.
+	Games []CreatedGame
+	// Page counts from 1. PrevPage and NextPage are zero if there isn't
+	// one.
+	Page     int
+	PrevPage int
+	NextPage int
+	// Deleted is how many games were just deleted, if the player deleted
+	// some.
+	Deleted *int
+}
+
+// CreatedGame is a game in a creator's list.
+type CreatedGame struct {
+	AdminToken string
+	Token      string
+	Answer     string
+	Date       string
+	Players    int
+	WinRate    int
+}
+
+// RenderTo renders the creator's games.
+func (m MyGames) RenderTo(w io.Writer) error {
+	return myGamesTmpl.Execute(w, m)
+}
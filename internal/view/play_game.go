@@ -15,9 +15,31 @@ var playGameTmpl = template.Must(template.New("play-game").Parse(playGameHTML))
 
 // PlayGame is the play game page.
 type PlayGame struct {
+	CSRFToken string
 	Token     string
 	Length    int
 	GameState *wording.GameState
+	// OpensAt is set, instead of GameState, if the game hasn't opened
+	// yet. OpensIn counts down to Opens, in Unix seconds.
+	OpensAt string
+	OpensIn string
+	Opens   int64
+	// ClosesAt is set if the game is open and will close.
+	ClosesAt string
+	// Answer is only set once the game's settings let the player see it.
+	Answer string
+	// Closed is set once the game has closed, when its Leaderboard is
+	// shown.
+	Closed      bool
+	Leaderboard []Standing
+}
+
+// Standing is a winner's place on a closed game's leaderboard. Winners are
+// kept anonymous, except to themselves.
+type Standing struct {
+	Rank    int
+	Guesses int
+	You     bool
 }
 
 // RenderTo renders the play game page.
@@ -0,0 +1,38 @@
+package view
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed recover.tmpl.html
+var recoverHTML string
+
+var recoverTmpl = template.Must(template.New("recover").Parse(recoverHTML))
+
+// Recover asks for a recovery email to send a recovery link to, has the
+// link confirmed, then shows the new admin links.
+type Recover struct {
+	CSRFToken string
+	BaseURL   string
+	// Sent is set once the request has been made.
+	Sent bool
+	// Token is set when confirming a recovery link.
+	Token string
+	// Recovered is set once the link has been used, and Games are the
+	// games that were given new admin links.
+	Recovered bool
+	Games     []RecoveredGame
+}
+
+// RecoveredGame is a game with a new admin link.
+type RecoveredGame struct {
+	AdminToken string
+	Token      string
+}
+
+// RenderTo renders the recovery page.
+func (rc Recover) RenderTo(w io.Writer) error {
+	return recoverTmpl.Execute(w, rc)
+}
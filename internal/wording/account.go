@@ -0,0 +1,41 @@
+package wording
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Account lets a player log in with their email address to keep their
+// games and stats across devices. Everything an account owns is keyed by
+// its player token, just like an anonymous player's.
+type Account struct {
+	ID          int64
+	PlayerToken string
+	CreatedAt   time.Time
+}
+
+// Session is a logged-in browser.
+type Session struct {
+	Token     string
+	Account   Account
+	ExpiresAt time.Time
+}
+
+// ValidateEmail validates a user-supplied email address. It must be a bare
+// address, without a display name.
+func ValidateEmail(email string) error {
+	violations := make(InputViolations)
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != strings.TrimSpace(email) {
+		violations["email"] = append(violations["email"], errors.New("must be an email address"))
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package wording
+
+import "time"
+
+// Things operators can do to a game, as recorded in the audit log.
+const (
+	AuditViewGame    = "view"
+	AuditDisableGame = "disable"
+	AuditEnableGame  = "enable"
+	AuditDeleteGame  = "delete"
+)
+
+// AuditEntry records something an operator did to a game.
+type AuditEntry struct {
+	Time     time.Time
+	Operator string
+	Action   string
+	// GameToken is the game's player token, which is kept after the game
+	// is deleted.
+	GameToken string
+}
+
+// GameSearch narrows down the games listed for operators.
+type GameSearch struct {
+	// Token matches games whose player or admin token starts with it.
+	Token string
+	// CreatedFrom and CreatedBefore limit the games to those created at
+	// or after one time and before another. Either may be zero to leave
+	// that end open.
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+	// ByActivity lists the most recently played or managed games first,
+	// rather than the newest.
+	ByActivity bool
+}
+
+// ListedGame is a game as listed for operators. Answers aren't included,
+// since looking at one is audited.
+type ListedGame struct {
+	Game
+	CreatedAt time.Time
+	// AccessedAt is when the game was last played or managed.
+	AccessedAt time.Time
+	Players    int
+}
+
+// ListedGames is a page of the games found by a search.
+type ListedGames struct {
+	Games []ListedGame
+	// Page counts from 1. More is set if there are later pages.
+	Page int
+	More bool
+}
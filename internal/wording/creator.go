@@ -0,0 +1,29 @@
+package wording
+
+import "time"
+
+// CreatedGame is a game as listed for whoever created it.
+type CreatedGame struct {
+	Game
+	CreatedAt time.Time
+	// Players is how many players have made a guess, and Wins how many of
+	// them guessed the answer.
+	Players int
+	Wins    int
+}
+
+// WinRate is the percentage of players who guessed the answer.
+func (g *CreatedGame) WinRate() int {
+	if g.Players == 0 {
+		return 0
+	}
+	return g.Wins * 100 / g.Players
+}
+
+// CreatedGames is a page of the games a player created, newest first.
+type CreatedGames struct {
+	Games []CreatedGame
+	// Page counts from 1. More is set if there are later pages.
+	Page int
+	More bool
+}
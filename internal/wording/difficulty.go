@@ -0,0 +1,152 @@
+package wording
+
+import "math"
+
+// letterFrequency is how often each letter appears in English text, in
+// percent.
+var letterFrequency = map[rune]float64{
+	'a': 8.2, 'b': 1.5, 'c': 2.8, 'd': 4.3, 'e': 12.7, 'f': 2.2, 'g': 2.0,
+	'h': 6.1, 'i': 7.0, 'j': 0.15, 'k': 0.77, 'l': 4.0, 'm': 2.4, 'n': 6.7,
+	'o': 7.5, 'p': 1.9, 'q': 0.095, 'r': 6.0, 's': 6.3, 't': 9.1, 'u': 2.8,
+	'v': 0.98, 'w': 2.4, 'x': 0.15, 'y': 2.0, 'z': 0.074,
+}
+
+// DifficultyFactors are the parts of a difficulty rating that need a word
+// list. The zero value means no word list was available.
+type DifficultyFactors struct {
+	// HaveWordList reports whether the other fields were measured.
+	HaveWordList bool
+	// InWordList reports whether the answer is a known word.
+	InWordList bool
+	// Rarity is how uncommon the answer is among known words of its
+	// length, from 0 (most common) to 1 (rarest).
+	Rarity float64
+	// SolverGuesses is how many guesses a solver needed to find the
+	// answer, or zero if it wasn't simulated.
+	SolverGuesses int
+}
+
+// Difficulty is how hard an answer is expected to be to guess.
+type Difficulty struct {
+	// Score ranges from 0 (trivial) to 100 (brutal).
+	Score int
+	// SolverGuesses is how many guesses a solver needed, or zero if
+	// unknown.
+	SolverGuesses int
+}
+
+// RateDifficulty scores an answer by how common its letters are, how many
+// of them repeat, how rare the word is and how long a solver took to find
+// it. Factors that weren't measured are left out of the score.
+func RateDifficulty(answer string, f DifficultyFactors) Difficulty {
+	type part struct {
+		weight, value float64
+	}
+
+	parts := []part{
+		{0.3, uncommonLetters(answer)},
+		{0.15, repeatedLetters(answer)},
+	}
+
+	if f.HaveWordList {
+		rarity := 1.0
+		if f.InWordList {
+			rarity = f.Rarity
+		}
+		parts = append(parts, part{0.25, rarity})
+	}
+
+	if f.SolverGuesses > 0 {
+		// One guess is a lucky opener; six or more is as hard as it gets.
+		parts = append(parts, part{0.3, clamp(float64(f.SolverGuesses-1) / 5)})
+	}
+
+	var sum, weights float64
+	for _, p := range parts {
+		sum += p.weight * p.value
+		weights += p.weight
+	}
+
+	return Difficulty{
+		Score:         int(math.Round(100 * sum / weights)),
+		SolverGuesses: f.SolverGuesses,
+	}
+}
+
+// Label names the difficulty for people.
+func (d Difficulty) Label() string {
+	switch {
+	case d.Score < 25:
+		return "easy"
+	case d.Score < 50:
+		return "medium"
+	case d.Score < 75:
+		return "hard"
+	default:
+		return "brutal"
+	}
+}
+
+// ExpectedGuesses is roughly how many guesses a typical player needs.
+// Players are assumed to need one more guess than the solver.
+func (d Difficulty) ExpectedGuesses() int {
+	if d.SolverGuesses > 0 {
+		return d.SolverGuesses + 1
+	}
+	return 3 + int(math.Round(float64(d.Score)*4/100))
+}
+
+// LimitTooLow reports whether a typical player is likely to run out of
+// guesses.
+func (d Difficulty) LimitTooLow(guessLimit int) bool {
+	return guessLimit < d.ExpectedGuesses()
+}
+
+// uncommonLetters is 0 for answers made of the most common letters and
+// approaches 1 for ones made of the rarest.
+func uncommonLetters(answer string) float64 {
+	if answer == "" {
+		return 0
+	}
+
+	var sum float64
+	var n int
+	for _, r := range answer {
+		sum += 1 - letterFrequency[toLower(r)]/letterFrequency['e']
+		n++
+	}
+
+	return sum / float64(n)
+}
+
+// repeatedLetters is the fraction of letters that repeat an earlier one.
+func repeatedLetters(answer string) float64 {
+	seen := make(map[rune]bool)
+
+	var repeats, n int
+	for _, r := range answer {
+		r = toLower(r)
+		if seen[r] {
+			repeats++
+		}
+		seen[r] = true
+		n++
+	}
+
+	if n < 2 {
+		return 0
+	}
+
+	return float64(repeats) / float64(n-1)
+}
+
+func toLower(r rune) rune {
+	if r >= 'A' && r <= 'Z' {
+		return r + 'a' - 'A'
+	}
+	return r
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -12,6 +12,17 @@ type Game struct {
 	Token      string
 	Answer     string
 	GuessLimit int
+	// Difficulty is how hard the answer was rated when the game was
+	// created, or nil for games created before answers were rated.
+	Difficulty *Difficulty
+	// Recoverable is set if new admin links can be emailed to the
+	// creator. It's only filled in when a game is fetched by its admin
+	// token.
+	Recoverable bool
+	// Disabled is set if an operator has stopped the game from being
+	// played.
+	Disabled bool
+	GameSettings
 }
 
 // Character is a letter that a player has entered as part
@@ -43,6 +54,9 @@ type GameState struct {
 	CanContinue  bool
 	IsVictorious bool
 	GameOver     bool
+	// Answer is only set once the game's settings allow the player to
+	// see it.
+	Answer string
 }
 
 // Evaluate inspects a player's guess and provides necessary decoration/
@@ -107,6 +121,10 @@ func ValidateGuessLimit(limit int) error {
 func ValidateAnswer(answer string) error {
 	violations := make(InputViolations)
 
+	if answer == "" {
+		violations["answer"] = append(violations["answer"], errors.New("is missing"))
+	}
+
 	if !isAlpha(answer) {
 		violations["answer"] = append(violations["answer"], errors.New("has non-alphabetical characters"))
 	}
@@ -0,0 +1,113 @@
+package wording
+
+import "time"
+
+// PlayerGame is a game a player has played, along with their attempts.
+type PlayerGame struct {
+	Token      string
+	Answer     string
+	GuessLimit int
+	// StartedAt is when the player made their first guess.
+	StartedAt time.Time
+	Plays
+	GameSettings
+}
+
+// Won reports whether the player guessed the answer.
+func (g *PlayerGame) Won() bool {
+	for _, guess := range g.Attempts {
+		if guess == g.Answer {
+			return true
+		}
+	}
+	return false
+}
+
+// Finished reports whether the player has won or used all of their
+// guesses.
+func (g *PlayerGame) Finished() bool {
+	return g.Won() || len(g.Attempts) >= g.GuessLimit
+}
+
+// PlayerStats sum up a player's history across games.
+type PlayerStats struct {
+	GamesPlayed   int
+	GamesFinished int
+	GamesWon      int
+	// CurrentStreak is how many days in a row, up to today or yesterday,
+	// the player has won at least one game. MaxStreak is the longest such
+	// run ever.
+	CurrentStreak int
+	MaxStreak     int
+	// WinsIn counts wins by how many guesses they took: WinsIn[0] is the
+	// number of games won in one.
+	WinsIn []int
+}
+
+// WinRate is the percentage of finished games that were won.
+func (s *PlayerStats) WinRate() int {
+	if s.GamesFinished == 0 {
+		return 0
+	}
+	return s.GamesWon * 100 / s.GamesFinished
+}
+
+// TallyPlayerStats works out a player's stats from their games. Days are
+// in UTC, and now decides whether the latest streak is still going.
+func TallyPlayerStats(games []PlayerGame, now time.Time) PlayerStats {
+	var stats PlayerStats
+	winDays := make(map[time.Time]bool)
+
+	for i := range games {
+		g := &games[i]
+		if len(g.Attempts) == 0 {
+			continue
+		}
+
+		stats.GamesPlayed++
+		if g.Finished() {
+			stats.GamesFinished++
+		}
+		if !g.Won() {
+			continue
+		}
+
+		stats.GamesWon++
+		winDays[Day(g.StartedAt)] = true
+
+		for n, guess := range g.Attempts {
+			if guess != g.Answer {
+				continue
+			}
+			for len(stats.WinsIn) <= n {
+				stats.WinsIn = append(stats.WinsIn, 0)
+			}
+			stats.WinsIn[n]++
+			break
+		}
+	}
+
+	for day := range winDays {
+		// Only count runs from their first day.
+		if winDays[day.AddDate(0, 0, -1)] {
+			continue
+		}
+
+		run := 1
+		for winDays[day.AddDate(0, 0, run)] {
+			run++
+		}
+
+		if run > stats.MaxStreak {
+			stats.MaxStreak = run
+		}
+
+		last := day.AddDate(0, 0, run-1)
+		today := Day(now)
+		if last.Equal(today) || last.Equal(today.AddDate(0, 0, -1)) {
+			stats.CurrentStreak = run
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,24 @@
+package wording
+
+import "time"
+
+// GameRecord is a game along with everything played against it, for
+// backing games up and moving them between instances.
+type GameRecord struct {
+	Game
+	CreatedAt time.Time
+	// Creator is the player token of whoever created the game, if it was
+	// created by a player who can be told apart.
+	Creator string
+	// RecoveryEmailHash is the hash of the address new admin links are
+	// sent to, if one was set.
+	RecoveryEmailHash string
+	Players           []PlayerRecord
+}
+
+// PlayerRecord is one player's attempts at a game.
+type PlayerRecord struct {
+	PlayerToken string
+	CreatedAt   time.Time
+	Plays
+}
@@ -0,0 +1,122 @@
+package wording
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Reveal is when players who didn't guess a game's answer are shown it.
+type Reveal string
+
+const (
+	// RevealOnLoss shows the answer to each player as soon as they run
+	// out of guesses, and to everyone once the game closes.
+	RevealOnLoss Reveal = "loss"
+	// RevealOnClose only shows the answer once the game has closed.
+	RevealOnClose Reveal = "close"
+	// RevealNever keeps the answer from everyone who didn't guess it.
+	RevealNever Reveal = "never"
+)
+
+// GameSettings are what a game's creator can change after creating it.
+type GameSettings struct {
+	// OpensAt and ClosesAt are when players can start and stop guessing.
+	// Either may be zero to leave that end open.
+	OpensAt  time.Time
+	ClosesAt time.Time
+	// Reveal is empty until defaulted to RevealOnLoss by WithDefaults.
+	Reveal Reveal
+}
+
+// WithDefaults fills in the settings that were left out.
+func (s GameSettings) WithDefaults() GameSettings {
+	if s.Reveal == "" {
+		s.Reveal = RevealOnLoss
+	}
+	return s
+}
+
+// Opened reports whether the game has opened by now.
+func (s GameSettings) Opened(now time.Time) bool {
+	return s.OpensAt.IsZero() || !now.Before(s.OpensAt)
+}
+
+// Closed reports whether the game has closed by now.
+func (s GameSettings) Closed(now time.Time) bool {
+	return !s.ClosesAt.IsZero() && !now.Before(s.ClosesAt)
+}
+
+// RevealsAnswer reports whether a player may be shown the answer by now,
+// given whether they won and whether they've finished playing. Winners
+// already know it.
+func (s GameSettings) RevealsAnswer(won, finished bool, now time.Time) bool {
+	switch {
+	case won:
+		return true
+	case s.Reveal == RevealOnLoss:
+		return finished || s.Closed(now)
+	case s.Reveal == RevealOnClose:
+		return s.Closed(now)
+	}
+	return false
+}
+
+// ValidateSettings validates a creator's settings.
+func ValidateSettings(s GameSettings) error {
+	violations := make(InputViolations)
+
+	if !s.OpensAt.IsZero() && !s.ClosesAt.IsZero() && !s.ClosesAt.After(s.OpensAt) {
+		violations["closes at"] = append(violations["closes at"], errors.New("must be after the game opens"))
+	}
+
+	switch s.Reveal {
+	case RevealOnLoss, RevealOnClose, RevealNever:
+	default:
+		violations["reveal"] = append(violations["reveal"], fmt.Errorf("must be %q, %q or %q", RevealOnLoss, RevealOnClose, RevealNever))
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+
+	return nil
+}
+
+// Standing is a winner's place on a game's leaderboard.
+type Standing struct {
+	// Rank counts from 1. Winners who needed as many guesses share a
+	// rank.
+	Rank        int
+	PlayerToken string
+	Guesses     int
+}
+
+// Leaderboard ranks the players who guessed the answer by how few guesses
+// they needed, then by who started first.
+func Leaderboard(answer string, players []PlayerRecord) []Standing {
+	var winners []PlayerRecord
+	for _, p := range players {
+		if n := len(p.Attempts); n > 0 && p.Attempts[n-1] == answer {
+			winners = append(winners, p)
+		}
+	}
+
+	sort.SliceStable(winners, func(i, j int) bool {
+		if len(winners[i].Attempts) != len(winners[j].Attempts) {
+			return len(winners[i].Attempts) < len(winners[j].Attempts)
+		}
+		return winners[i].CreatedAt.Before(winners[j].CreatedAt)
+	})
+
+	standings := make([]Standing, len(winners))
+	for i, p := range winners {
+		standings[i] = Standing{Rank: i + 1, PlayerToken: p.PlayerToken, Guesses: len(p.Attempts)}
+		if i > 0 && standings[i-1].Guesses == standings[i].Guesses {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+
+	return standings
+}
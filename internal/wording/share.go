@@ -0,0 +1,35 @@
+package wording
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShareText summarizes a player's game as a grid of colored squares that
+// doesn't give away any letters, so it can be shared with people who are
+// still playing.
+func (s *GameState) ShareText(name string, guessLimit int) string {
+	var b strings.Builder
+
+	score := "X"
+	if s.IsVictorious {
+		score = fmt.Sprint(len(s.Attempts))
+	}
+	fmt.Fprintf(&b, "%s %s/%d\n", name, score, guessLimit)
+
+	for _, attempt := range s.Attempts {
+		b.WriteByte('\n')
+		for _, ch := range attempt {
+			switch {
+			case ch.IsCorrect:
+				b.WriteString("🟩")
+			case ch.IsPartial:
+				b.WriteString("🟨")
+			default:
+				b.WriteString("⬛")
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -1,5 +1,10 @@
 package wording
 
+import (
+	"sort"
+	"time"
+)
+
 // LifetimeScope is the name of the row where global game stats are stored.
 const LifetimeScope = "lifetime"
 
@@ -15,3 +20,147 @@ type Stats struct {
 type IncrementStats struct {
 	Stats
 }
+
+// DailyStats are the stats for a single day, either for one game or for
+// every game.
+type DailyStats struct {
+	// Day is midnight UTC at the start of the day.
+	Day            time.Time
+	GamesCreated   int
+	PlayersStarted int
+	GuessesMade    int
+	GamesWon       int
+	// WinsIn counts wins by how many guesses they took: WinsIn[0] is the
+	// number of players who got it in one.
+	WinsIn []int
+}
+
+// PlayOutcome is what a player's latest guess changed, for keeping daily
+// stats.
+type PlayOutcome struct {
+	// Started is set for the player's first guess.
+	Started bool
+	// Won is set if the guess was the answer.
+	Won bool
+}
+
+// Day truncates t to midnight UTC, which is how DailyStats are bucketed.
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// FillDays returns one entry per day from the day of from through the day
+// of to, taking them from days where they exist and leaving the rest
+// empty, so gaps show up in a time series. days must be in order.
+func FillDays(days []DailyStats, from, to time.Time) []DailyStats {
+	from, to = Day(from), Day(to)
+
+	var filled []DailyStats
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for len(days) > 0 && days[0].Day.Before(day) {
+			days = days[1:]
+		}
+
+		if len(days) > 0 && days[0].Day.Equal(day) {
+			filled = append(filled, days[0])
+			continue
+		}
+
+		filled = append(filled, DailyStats{Day: day})
+	}
+
+	return filled
+}
+
+// GameStats are everything there is to know about how a single game has
+// been played.
+type GameStats struct {
+	Stats
+	PlayersStarted int
+	// PlayersFinished have either won or used all of their guesses.
+	PlayersFinished int
+	Losses          int
+	// WinsIn counts wins by how many guesses they took: WinsIn[0] is the
+	// number of players who got it in one. It's as long as the guess limit.
+	WinsIn []int
+	// FirstGuesses are the most common opening guesses, most common first.
+	FirstGuesses []WordCount
+	// WrongFinalGuesses are the most common last guesses of players who
+	// lost, most common first.
+	WrongFinalGuesses []WordCount
+}
+
+// WordCount is how many times a word was guessed.
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// TopGuesses is how many of the most common guesses GameStats keeps.
+const TopGuesses = 5
+
+// TallyGameStats works out a game's stats from every player's attempts.
+func TallyGameStats(answer string, guessLimit int, players []Plays) GameStats {
+	stats := GameStats{WinsIn: make([]int, guessLimit)}
+
+	first := make(map[string]int)
+	wrongFinal := make(map[string]int)
+
+	for _, p := range players {
+		if len(p.Attempts) == 0 {
+			continue
+		}
+
+		stats.PlayersStarted++
+		stats.GuessesMade += len(p.Attempts)
+		first[p.Attempts[0]]++
+
+		won := false
+		for i, guess := range p.Attempts {
+			if guess == answer {
+				won = true
+				if i < len(stats.WinsIn) {
+					stats.WinsIn[i]++
+				}
+				break
+			}
+		}
+
+		switch {
+		case won:
+			stats.GamesWon++
+			stats.PlayersFinished++
+		case len(p.Attempts) >= guessLimit:
+			stats.Losses++
+			stats.PlayersFinished++
+			wrongFinal[p.Attempts[len(p.Attempts)-1]]++
+		}
+	}
+
+	stats.FirstGuesses = topWords(first, TopGuesses)
+	stats.WrongFinalGuesses = topWords(wrongFinal, TopGuesses)
+
+	return stats
+}
+
+// topWords returns the n most common words, breaking ties alphabetically.
+func topWords(counts map[string]int, n int) []WordCount {
+	words := make([]WordCount, 0, len(counts))
+	for word, count := range counts {
+		words = append(words, WordCount{Word: word, Count: count})
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+
+	if len(words) > n {
+		words = words[:n]
+	}
+
+	return words
+}
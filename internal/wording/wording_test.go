@@ -2,6 +2,7 @@ package wording
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 )
@@ -50,3 +51,205 @@ func TestEvaluate(t *testing.T) {
 		})
 	}
 }
+
+func TestShareText(t *testing.T) {
+	plays := Plays{Attempts: []string{"tomato", "potato"}}
+	state := plays.Evaluate("potato", 6)
+
+	assert.Equal(t, "Wording 2/6\n\n🟨🟩⬛🟩🟩🟩\n🟩🟩🟩🟩🟩🟩", state.ShareText("Wording", 6))
+
+	plays = Plays{Attempts: []string{"tomato"}}
+	state = plays.Evaluate("potato", 1)
+
+	assert.Equal(t, "Wording X/1\n\n🟨🟩⬛🟩🟩🟩", state.ShareText("Wording", 1))
+}
+
+func TestRateDifficulty(t *testing.T) {
+	easy := RateDifficulty("tears", DifficultyFactors{
+		HaveWordList:  true,
+		InWordList:    true,
+		Rarity:        0.1,
+		SolverGuesses: 2,
+	})
+	brutal := RateDifficulty("jazzy", DifficultyFactors{
+		HaveWordList:  true,
+		SolverGuesses: 7,
+	})
+
+	assert.Equal(t, "easy", easy.Label())
+	assert.Equal(t, "brutal", brutal.Label())
+	assert.Equal(t, 100, RateDifficulty("zzzz", DifficultyFactors{HaveWordList: true, SolverGuesses: 6}).Score)
+
+	// Without a word list only the letters count.
+	assert.Assert(t, RateDifficulty("jazzy", DifficultyFactors{}).Score > RateDifficulty("tears", DifficultyFactors{}).Score)
+	assert.Equal(t, 0, RateDifficulty("tears", DifficultyFactors{}).SolverGuesses)
+}
+
+func TestDifficultyLimitTooLow(t *testing.T) {
+	d := Difficulty{Score: 50, SolverGuesses: 4}
+	assert.Equal(t, 5, d.ExpectedGuesses())
+	assert.Assert(t, d.LimitTooLow(4))
+	assert.Assert(t, !d.LimitTooLow(5))
+
+	d = Difficulty{Score: 50}
+	assert.Equal(t, 5, d.ExpectedGuesses())
+}
+
+func TestFillDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, time.January, d, 0, 0, 0, 0, time.UTC) }
+
+	got := FillDays([]DailyStats{
+		{Day: day(1), GuessesMade: 9},
+		{Day: day(3), GuessesMade: 3},
+		{Day: day(5), GuessesMade: 5},
+	}, day(2).Add(13*time.Hour), day(4))
+
+	assert.DeepEqual(t, []DailyStats{
+		{Day: day(2)},
+		{Day: day(3), GuessesMade: 3},
+		{Day: day(4)},
+	}, got)
+}
+
+func TestTallyGameStats(t *testing.T) {
+	got := TallyGameStats("potato", 3, []Plays{
+		{Attempts: []string{"tomato", "potato"}},
+		{Attempts: []string{"potato"}},
+		{Attempts: []string{"tomato", "banana", "carrot"}},
+		{Attempts: []string{"carrot", "banana", "carrot"}},
+		{Attempts: []string{"banana"}},
+		{},
+	})
+
+	assert.DeepEqual(t, GameStats{
+		Stats:             Stats{GamesWon: 2, GuessesMade: 10},
+		PlayersStarted:    5,
+		PlayersFinished:   4,
+		Losses:            2,
+		WinsIn:            []int{1, 1, 0},
+		FirstGuesses:      []WordCount{{"tomato", 2}, {"banana", 1}, {"carrot", 1}, {"potato", 1}},
+		WrongFinalGuesses: []WordCount{{"carrot", 2}},
+	}, got)
+}
+
+func TestTallyPlayerStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, time.January, d, 18, 0, 0, 0, time.UTC) }
+	won := func(d int, attempts ...string) PlayerGame {
+		return PlayerGame{Answer: "potato", GuessLimit: 3, StartedAt: day(d), Plays: Plays{Attempts: append(attempts, "potato")}}
+	}
+
+	games := []PlayerGame{
+		won(1),
+		won(2, "tomato"),
+		won(2, "tomato", "banana"),
+		won(3),
+		{Answer: "potato", GuessLimit: 3, StartedAt: day(4), Plays: Plays{Attempts: []string{"a", "b", "c"}}},
+		won(6, "tomato"),
+		won(7),
+		{Answer: "potato", GuessLimit: 3, StartedAt: day(7), Plays: Plays{Attempts: []string{"tomato"}}},
+		{Answer: "potato", GuessLimit: 3, StartedAt: day(7)},
+	}
+
+	got := TallyPlayerStats(games, day(8))
+	assert.DeepEqual(t, PlayerStats{
+		GamesPlayed:   8,
+		GamesFinished: 7,
+		GamesWon:      6,
+		CurrentStreak: 2,
+		MaxStreak:     3,
+		WinsIn:        []int{3, 2, 1},
+	}, got)
+	assert.Equal(t, 85, got.WinRate())
+
+	// The streak is broken once a whole day goes by without a win.
+	got = TallyPlayerStats(games, day(9))
+	assert.Equal(t, 0, got.CurrentStreak)
+	assert.Equal(t, 3, got.MaxStreak)
+}
+
+func TestValidateAnswer(t *testing.T) {
+	for answer, valid := range map[string]bool{
+		"potato":  true,
+		"POTATO":  true,
+		"":        false,
+		"pot ato": false,
+		"p0tato":  false,
+	} {
+		err := ValidateAnswer(answer)
+		assert.Equal(t, valid, err == nil, "%q: %v", answer, err)
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	for email, valid := range map[string]bool{
+		"player@example.com":           true,
+		"player+wording@example.com":   true,
+		"player":                       false,
+		"Player <player@example.com>":  false,
+		"player@example.com\r\nBcc: x": false,
+	} {
+		err := ValidateEmail(email)
+		assert.Equal(t, valid, err == nil, "%q: %v", email, err)
+	}
+}
+
+func TestGameSettingsSchedule(t *testing.T) {
+	opens := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+	s := GameSettings{OpensAt: opens, ClosesAt: opens.Add(time.Hour)}
+
+	assert.Assert(t, !s.Opened(opens.Add(-time.Second)))
+	assert.Assert(t, s.Opened(opens))
+	assert.Assert(t, !s.Closed(opens.Add(time.Hour-time.Second)))
+	assert.Assert(t, s.Closed(opens.Add(time.Hour)))
+
+	var unscheduled GameSettings
+	assert.Assert(t, unscheduled.Opened(opens) && !unscheduled.Closed(opens))
+
+	assert.NilError(t, ValidateSettings(s.WithDefaults()))
+	assert.ErrorContains(t, ValidateSettings(GameSettings{OpensAt: opens, ClosesAt: opens, Reveal: RevealNever}), "must be after the game opens")
+	assert.ErrorContains(t, ValidateSettings(GameSettings{Reveal: "sometimes"}), "reveal")
+}
+
+func TestRevealsAnswer(t *testing.T) {
+	closes := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+	before, after := closes.Add(-time.Second), closes
+
+	tests := []struct {
+		reveal        Reveal
+		won, finished bool
+		now           time.Time
+		want          bool
+	}{
+		{RevealOnLoss, false, false, before, false},
+		{RevealOnLoss, false, true, before, true},
+		{RevealOnLoss, false, false, after, true},
+		{RevealOnClose, false, true, before, false},
+		{RevealOnClose, false, false, after, true},
+		{RevealNever, false, true, after, false},
+		{RevealNever, true, true, before, true},
+	}
+
+	for _, tt := range tests {
+		s := GameSettings{ClosesAt: closes, Reveal: tt.reveal}
+		got := s.RevealsAnswer(tt.won, tt.finished, tt.now)
+		assert.Equal(t, tt.want, got, "%s won=%v finished=%v now=%v", tt.reveal, tt.won, tt.finished, tt.now)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	start := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	got := Leaderboard("potato", []PlayerRecord{
+		{PlayerToken: "slow", CreatedAt: start, Plays: Plays{Attempts: []string{"tomato", "banana", "potato"}}},
+		{PlayerToken: "lost", CreatedAt: start, Plays: Plays{Attempts: []string{"tomato", "banana", "carrot"}}},
+		{PlayerToken: "late", CreatedAt: start.Add(time.Minute), Plays: Plays{Attempts: []string{"tomato", "potato"}}},
+		{PlayerToken: "early", CreatedAt: start, Plays: Plays{Attempts: []string{"banana", "potato"}}},
+		{PlayerToken: "playing", CreatedAt: start, Plays: Plays{Attempts: []string{"tomato"}}},
+	})
+
+	assert.DeepEqual(t, []Standing{
+		{Rank: 1, PlayerToken: "early", Guesses: 2},
+		{Rank: 1, PlayerToken: "late", Guesses: 2},
+		{Rank: 3, PlayerToken: "slow", Guesses: 3},
+	}, got)
+}
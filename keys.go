@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/connorkuehl/wording/internal/store"
+)
+
+// rotateKeys re-encrypts answers after a new answer key has been put at
+// the front of answer-keys, so the old one can be dropped. Plaintext
+// answers from before encryption was set up are encrypted too.
+func (a *app) rotateKeys(ctx context.Context, args []string) error {
+	fs := a.flagSet("rotate-keys")
+	decrypt := fs.Bool("decrypt", false, "Store answers as plaintext instead, e.g., before reverting the migration that added key IDs")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return a.usageError("rotate-keys: unexpected arguments %q", fs.Args())
+	}
+
+	answerSealer, err := a.sealer()
+	if err != nil {
+		return err
+	}
+	if answerSealer == nil {
+		return a.usageError("rotate-keys: answer-keys must be set")
+	}
+
+	st, err := store.NewPostgresStore(a.cfg.DBDSN, answerSealer, a.log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := st.Close()
+		if err != nil {
+			a.log.WithError(err).Warn("closing database")
+		}
+	}()
+
+	n, err := st.RotateKeys(ctx, *decrypt)
+	if err != nil {
+		return err
+	}
+
+	if *decrypt {
+		fmt.Fprintf(a.stdout, "decrypted %d answers\n", n)
+	} else {
+		fmt.Fprintf(a.stdout, "encrypted %d answers with key %s\n", n, answerSealer.KeyID())
+	}
+
+	return nil
+}
@@ -1,83 +1,287 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
-	"net/http"
+	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/connorkuehl/wording/internal/config"
 	"github.com/connorkuehl/wording/internal/generator"
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/mail"
 	"github.com/connorkuehl/wording/internal/randword"
+	"github.com/connorkuehl/wording/internal/sealer"
 	"github.com/connorkuehl/wording/internal/server"
 	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/solver"
 	"github.com/connorkuehl/wording/internal/store"
 )
 
+const usage = `Usage: wording [flags] [command]
+
+Commands:
+  serve                                Run the web server (the default)
+  migrate [up | down [N] | version]    Manage the database schema
+  game create -answer WORD -limit N    Create a game and print its links
+  game show ADMIN-TOKEN                Show a game and its stats
+  game delete ADMIN-TOKEN...           Delete games and their plays
+  stats                                Show lifetime stats
+  prune [-idle-after DURATION]         Delete games that nobody has touched
+  export [-o FILE]                     Write every game, its plays and stats as JSON Lines
+  import [-fresh-tokens] [-stats] [FILE]
+                                       Load games written by export
+  rotate-keys [-decrypt]               Re-encrypt answers with the newest answer key
+  play [-server URL] URL-OR-TOKEN      Play a game in the terminal
+  solve [-answer WORD] [GUESS=FEEDBACK...]
+                                       Suggest guesses, or show how hard an answer is
+
+Flags go before the command. Run "wording -h" to list them. The play and
+solve commands don't use them; run "wording play -h" or "wording solve -h"
+for their own flags.
+`
+
+// errUsage is returned when a command is given the wrong arguments. The
+// usage has already been printed.
+var errUsage = errors.New("invalid usage")
+
 func main() {
-	var config struct {
-		environment string
-		baseURL     string
-		dbDSN       string
-		bind        string
-		wordGenSvc  string
-	}
-
-	fromEnvOr := func(key, fallback string) string {
-		s := os.Getenv(key)
-		if s == "" {
-			return fallback
+	err := run(os.Args, os.Stdin, os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(os.Stderr, "\n"+usage)
+		return
+	}
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// app is what every command needs.
+type app struct {
+	cfg    *config.Config
+	log    *log.Logger
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	a := &app{
+		log:    log.StandardLogger(),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Players and puzzle authors don't run a server, so these commands
+	// don't need its configuration.
+	if len(args) > 1 {
+		switch args[1] {
+		case "play":
+			return a.play(ctx, args[2:])
+		case "solve":
+			return a.solve(ctx, args[2:])
 		}
-		return s
 	}
 
-	flag.StringVar(&config.environment, "environment", fromEnvOr("WORDING_ENVIRONMENT", "dev"), "Environment")
-	flag.StringVar(&config.baseURL, "base-url", os.Getenv("WORDING_BASE_URL"), "Base URL to prefix links with")
-	flag.StringVar(&config.dbDSN, "db-dsn", os.Getenv("WORDING_DB_DSN"), "Postgres DSN")
-	flag.StringVar(&config.bind, "bind-addr", os.Getenv("WORDING_BIND_ADDR"), "Bind address")
-	flag.StringVar(&config.wordGenSvc, "word-gen-svc", os.Getenv("WORDING_WORD_GEN_SVC"), "Word generator API")
-	flag.Parse()
+	cfg, args, err := config.Load(args[0], args[1:], os.Getenv)
+	if err != nil {
+		return err
+	}
+	a.cfg = cfg
+
+	err = logging.Configure(a.log, stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return serve(ctx, a)
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "serve":
+		return serve(ctx, a)
+	case "migrate":
+		return a.migrate(ctx, args)
+	case "game":
+		return a.game(ctx, args)
+	case "stats":
+		return a.stats(ctx, args)
+	case "prune":
+		return a.prune(ctx, args)
+	case "export":
+		return a.exportGames(ctx, args)
+	case "import":
+		return a.importGames(ctx, args)
+	case "rotate-keys":
+		return a.rotateKeys(ctx, args)
+	case "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return a.usageError("unknown command %q", cmd)
+	}
+}
+
+// usageError reports a mistake in the command line along with the usage.
+func (a *app) usageError(format string, v ...any) error {
+	fmt.Fprintf(a.stderr, "wording: "+format+"\n\n%s", append(v, usage)...)
+	return errUsage
+}
 
-	log.WithFields(log.Fields{
-		"bind-addr":    config.bind,
-		"base-url":     config.baseURL,
-		"word-gen-svc": config.wordGenSvc,
-	}).Info("starting up")
+// flagSet returns a flag set for a command that reports errors with the
+// command's usage.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
 
-	store, err := store.NewPostgresStore(config.dbDSN)
+// openService connects to the database and returns the same service the
+// server uses, so commands validate input exactly like the web UI. The
+// returned function closes the database.
+func (a *app) openService() (service.Service, func(), error) {
+	answerSealer, err := a.sealer()
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
-	defer store.Close()
 
-	log.Info("connected to database")
+	st, err := store.NewPostgresStore(a.cfg.DBDSN, answerSealer, a.log)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	adminTokenGenerator := generator.NewUUIDGenerator()
-	gameTokenGenerator := generator.NewFallibleGenerator(
-		generator.NewHumanReadable(randword.NewClient(config.wordGenSvc)),
-		generator.NewUUIDGenerator(),
-	)
+	closeStore := func() {
+		err := st.Close()
+		if err != nil {
+			a.log.WithError(err).Warn("closing database")
+		}
+	}
+
+	var gameTokenGenerator service.TokenGenerator = generator.NewUUIDGenerator()
+	if a.cfg.WordGenSvc != "" {
+		gameTokenGenerator = newHumanReadableGenerator(a.cfg.WordGenSvc, nil)
+	}
+
+	rater, err := a.rater()
+	if err != nil {
+		closeStore()
+		return nil, nil, err
+	}
+
+	// Players only log in through the server, so commands don't need to
+	// send mail.
+	svc := service.New(st, generator.NewUUIDGenerator(), gameTokenGenerator, rater, nil, a.log)
+
+	return svc, closeStore, nil
+}
+
+// sealer encrypts answers with the configured keys. It returns nil if
+// there aren't any, which is only allowed in the dev environment.
+func (a *app) sealer() (*sealer.Sealer, error) {
+	if len(a.cfg.AnswerKeys) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]sealer.Key, len(a.cfg.AnswerKeys))
+	for i, s := range a.cfg.AnswerKeys {
+		key, err := sealer.ParseKey(s)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	return sealer.New(keys...)
+}
 
-	var svc service.Service = service.New(store, adminTokenGenerator, gameTokenGenerator)
-	srv := server.New(config.baseURL, svc)
+// rater rates answers against the configured word list, if there is one.
+func (a *app) rater() (*solver.Rater, error) {
+	if a.cfg.Words == "" {
+		return solver.NewRater(nil), nil
+	}
+
+	s, err := loadSolver(a.cfg.Words)
+	if err != nil {
+		return nil, err
+	}
+
+	return solver.NewRater(s), nil
+}
+
+// mailer sends login links with the configured sender. It returns nil if
+// logging in is turned off. The returned function closes the mail file,
+// if there is one.
+func (a *app) mailer() (service.Mailer, func(), error) {
+	var sender mail.Sender
+	closeMail := func() {}
+
+	switch a.cfg.Mail.Sender {
+	case "none":
+		return nil, closeMail, nil
+	case "stdout":
+		sender = mail.NewWriter(a.stdout, a.cfg.Mail.From)
+	case "file":
+		f, err := os.OpenFile(a.cfg.Mail.File, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, nil, err
+		}
+		closeMail = func() {
+			err := f.Close()
+			if err != nil {
+				a.log.WithError(err).Warn("closing mail file")
+			}
+		}
+		sender = mail.NewWriter(f, a.cfg.Mail.From)
+	case "smtp":
+		sender = mail.NewSMTP(a.cfg.Mail.SMTPAddr, a.cfg.Mail.From, a.cfg.Mail.SMTPUsername, a.cfg.Mail.SMTPPassword)
+	default:
+		return nil, nil, fmt.Errorf("unknown mail sender %q", a.cfg.Mail.Sender)
+	}
+
+	return mail.NewLinks(sender, a.cfg.BaseURL), closeMail, nil
+}
 
-	router := chi.NewRouter()
-	router.Use(middleware.RequestID)
-	router.Use(middleware.RealIP)
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
+// operatorAuth lets operators into the admin console with the configured
+// method. It returns nil if the console is turned off.
+func (a *app) operatorAuth() (server.OperatorAuth, error) {
+	switch a.cfg.Admin.Auth {
+	case "none":
+		return nil, nil
+	case "basic":
+		return server.NewBasicAuth(a.cfg.Admin.Username, a.cfg.Admin.Password), nil
+	case "stub":
+		return server.StubAuth(a.cfg.Admin.Username), nil
+	default:
+		return nil, fmt.Errorf("unknown admin auth %q", a.cfg.Admin.Auth)
+	}
+}
 
-	router.Get("/", srv.Home)
-	router.Get("/manage/{admin_token}", srv.ManageGame)
-	router.Post("/games", srv.CreateGame)
-	router.Get("/game/{token}", srv.PlayGame)
-	router.Post("/game/{token}", srv.Guess)
-	router.Post("/manage/{admin_token}/delete", srv.DeleteGame)
-	router.Get("/health", func(_ http.ResponseWriter, _ *http.Request) {
-	})
+// newHumanReadableGenerator returns a generator for human-readable game
+// tokens that falls back to UUIDs. wrap, if not nil, decorates the
+// word-based generator.
+func newHumanReadableGenerator(
+	wordGenSvc string,
+	wrap func(generator.FallibleTokener) generator.FallibleTokener,
+) *generator.FallibleGenerator {
+	var try generator.FallibleTokener = generator.NewHumanReadable(randword.NewClient(wordGenSvc))
+	if wrap != nil {
+		try = wrap(try)
+	}
 
-	log.Info("listening")
-	log.Fatal(http.ListenAndServe(config.bind, router))
+	return generator.NewFallibleGenerator(try, generator.NewUUIDGenerator())
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/migrations"
+)
+
+// migrate applies or reverts the embedded schema migrations.
+func (a *app) migrate(ctx context.Context, args []string) error {
+	direction := "up"
+	if len(args) > 0 {
+		direction, args = args[0], args[1:]
+	}
+
+	steps := 1
+	switch {
+	case direction == "down" && len(args) == 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return a.usageError("migrate down: %q is not a positive number", args[0])
+		}
+		steps = n
+	case len(args) > 0:
+		return a.usageError("migrate %s: unexpected arguments %q", direction, args)
+	}
+
+	all, err := store.LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	st, err := store.NewPostgresStore(a.cfg.DBDSN, nil, a.log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := st.Close()
+		if err != nil {
+			a.log.WithError(err).Warn("closing database")
+		}
+	}()
+
+	var done []store.Migration
+	switch direction {
+	case "up":
+		done, err = st.MigrateUp(ctx, all)
+	case "down":
+		done, err = st.MigrateDown(ctx, all, steps)
+	case "version":
+	default:
+		return a.usageError("migrate: unknown direction %q", direction)
+	}
+
+	for _, m := range done {
+		fmt.Fprintf(a.stdout, "%s %06d_%s\n", direction, m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := st.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest := uint(0)
+	if len(all) > 0 {
+		latest = all[len(all)-1].Version
+	}
+
+	fmt.Fprintf(a.stdout, "version %d of %d", version, latest)
+	if dirty {
+		fmt.Fprint(a.stdout, " (dirty)")
+	}
+	fmt.Fprintln(a.stdout)
+
+	return nil
+}
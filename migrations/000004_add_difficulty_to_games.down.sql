@@ -0,0 +1,3 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS solver_guesses,
+    DROP COLUMN IF EXISTS difficulty;
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS difficulty INTEGER,
+    ADD COLUMN IF NOT EXISTS solver_guesses INTEGER;
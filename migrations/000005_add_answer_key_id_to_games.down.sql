@@ -0,0 +1,4 @@
+-- Run `wording rotate-keys -decrypt` first, or encrypted answers can no
+-- longer be read.
+ALTER TABLE games
+    DROP COLUMN IF EXISTS answer_key_id;
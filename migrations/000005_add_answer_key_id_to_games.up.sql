@@ -0,0 +1,4 @@
+-- Answers with a key ID are encrypted with that key. Answers without one
+-- are plaintext until `wording rotate-keys` encrypts them.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS answer_key_id TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS game_daily_stats;
+DROP TABLE IF EXISTS daily_stats;
@@ -0,0 +1,20 @@
+-- wins_in[n] counts wins in n guesses. It's as long as the highest guess
+-- limit a game can have.
+CREATE TABLE IF NOT EXISTS daily_stats (
+    day DATE PRIMARY KEY,
+    games_created INTEGER NOT NULL DEFAULT 0,
+    players_started INTEGER NOT NULL DEFAULT 0,
+    guesses_made INTEGER NOT NULL DEFAULT 0,
+    games_won INTEGER NOT NULL DEFAULT 0,
+    wins_in INTEGER[] NOT NULL DEFAULT array_fill(0, ARRAY[16])
+);
+
+CREATE TABLE IF NOT EXISTS game_daily_stats (
+    admin_token TEXT NOT NULL REFERENCES games (admin_token) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    players_started INTEGER NOT NULL DEFAULT 0,
+    guesses_made INTEGER NOT NULL DEFAULT 0,
+    games_won INTEGER NOT NULL DEFAULT 0,
+    wins_in INTEGER[] NOT NULL DEFAULT array_fill(0, ARRAY[16]),
+    PRIMARY KEY (admin_token, day)
+);
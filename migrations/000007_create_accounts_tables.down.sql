@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS games_creator_idx;
+ALTER TABLE games
+    DROP COLUMN IF EXISTS creator;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS login_tokens;
+DROP TABLE IF EXISTS accounts;
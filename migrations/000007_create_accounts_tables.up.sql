@@ -0,0 +1,28 @@
+-- Email addresses are only kept as hashes. They're needed in the clear
+-- just long enough to send a login link.
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGSERIAL PRIMARY KEY,
+    email_hash TEXT NOT NULL UNIQUE,
+    player_token TEXT NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS login_tokens (
+    token_hash TEXT PRIMARY KEY,
+    email_hash TEXT NOT NULL,
+    expires_at TIMESTAMP(0) WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    token_hash TEXT PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP(0) WITH TIME ZONE NOT NULL
+);
+
+-- creator is the player token of whoever created the game, if it was
+-- created from the web or the API.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS creator TEXT;
+
+CREATE INDEX IF NOT EXISTS games_creator_idx ON games (creator);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS games_recovery_email_hash_idx;
+ALTER TABLE games
+    DROP COLUMN IF EXISTS recovery_email_hash;
+ALTER TABLE game_daily_stats
+    DROP CONSTRAINT IF EXISTS game_daily_stats_admin_token_fkey,
+    ADD CONSTRAINT game_daily_stats_admin_token_fkey
+        FOREIGN KEY (admin_token) REFERENCES games (admin_token) ON DELETE CASCADE;
@@ -0,0 +1,12 @@
+-- Rotating a game's admin token carries its daily stats along.
+ALTER TABLE game_daily_stats
+    DROP CONSTRAINT IF EXISTS game_daily_stats_admin_token_fkey,
+    ADD CONSTRAINT game_daily_stats_admin_token_fkey
+        FOREIGN KEY (admin_token) REFERENCES games (admin_token) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- A hash of the address that new admin links are sent to if the creator
+-- loses theirs.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS recovery_email_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS games_recovery_email_hash_idx ON games (recovery_email_hash);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS audit_log;
+ALTER TABLE games
+    DROP COLUMN IF EXISTS disabled_at;
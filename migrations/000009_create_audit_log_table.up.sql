@@ -0,0 +1,13 @@
+-- Games disabled by an operator can't be played until they're enabled.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP(0) WITH TIME ZONE;
+
+-- Everything operators do from the admin console. Entries outlive the
+-- games they're about, so they name the game by its player token.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    operator TEXT NOT NULL,
+    action TEXT NOT NULL,
+    game_token TEXT NOT NULL
+);
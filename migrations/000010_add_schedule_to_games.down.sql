@@ -0,0 +1,3 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS closes_at,
+    DROP COLUMN IF EXISTS opens_at;
@@ -0,0 +1,4 @@
+-- When players can start and stop guessing. NULL leaves that end open.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS opens_at TIMESTAMP(0) WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS closes_at TIMESTAMP(0) WITH TIME ZONE;
@@ -0,0 +1,4 @@
+-- When players who didn't guess the answer are shown it: as soon as they
+-- lose ('loss'), once the game closes ('close') or never ('never').
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS reveal TEXT NOT NULL DEFAULT 'loss';
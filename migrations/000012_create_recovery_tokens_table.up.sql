@@ -0,0 +1,7 @@
+-- Recovery links prove that whoever asks for new admin links can read the
+-- recovery email. Like login tokens, they're only kept as hashes.
+CREATE TABLE IF NOT EXISTS recovery_tokens (
+    token_hash TEXT PRIMARY KEY,
+    email_hash TEXT NOT NULL,
+    expires_at TIMESTAMP(0) WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,13 @@
+// Package migrations embeds the database schema migrations so the binary
+// can apply them without a copy of this directory.
+//
+// Files are named NNNNNN_description.up.sql and NNNNNN_description.down.sql,
+// the layout golang-migrate expects, so either tool can manage a database.
+package migrations
+
+import "embed"
+
+// FS holds every migration file.
+//
+//go:embed *.sql
+var FS embed.FS
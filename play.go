@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/connorkuehl/wording/internal/api"
+	"github.com/connorkuehl/wording/internal/tui"
+)
+
+// play runs the terminal client against a server's API.
+func (a *app) play(ctx context.Context, args []string) error {
+	defaultServer := os.Getenv("WORDING_SERVER")
+	if defaultServer == "" {
+		defaultServer = "http://localhost:8080"
+	}
+
+	defaultPlayers, err := tui.DefaultPlayersPath()
+	if err != nil {
+		return err
+	}
+
+	fs := a.flagSet("play")
+	server := fs.String("server", defaultServer, "Server to play on when given a token instead of a link (env WORDING_SERVER)")
+	players := fs.String("players", defaultPlayers, "File that remembers your player token for each server")
+	noColor := fs.Bool("no-color", os.Getenv("NO_COLOR") != "", "Mark letters with brackets instead of colors (env NO_COLOR)")
+
+	err = fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 1 {
+		return a.usageError("play: expected a game link or token")
+	}
+
+	baseURL, token, err := parseGameRef(fs.Arg(0), *server)
+	if err != nil {
+		return a.usageError("play: %v", err)
+	}
+
+	store := tui.NewPlayers(*players)
+
+	playerToken, err := store.Token(baseURL)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", *players, err)
+	}
+
+	game := &tui.Game{
+		Client:   api.NewClient(baseURL, playerToken),
+		Token:    token,
+		Renderer: tui.Renderer{Color: !*noColor},
+		OnPlayerToken: func(token string) error {
+			return store.SaveToken(baseURL, token)
+		},
+	}
+
+	err = game.Play(ctx, a.stdin, a.stdout)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// parseGameRef accepts either a player link, like
+// https://wording.example/game/hungry-hippo, or a bare game token to be
+// played on server.
+func parseGameRef(ref, server string) (baseURL, token string, err error) {
+	if !strings.Contains(ref, "://") {
+		if ref == "" || strings.Contains(ref, "/") {
+			return "", "", fmt.Errorf("%q is not a game link or token", ref)
+		}
+		return strings.TrimSuffix(server, "/"), ref, nil
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix, token, ok := strings.Cut(u.Path, "/game/")
+	if !ok || token == "" || strings.Contains(token, "/") {
+		return "", "", fmt.Errorf("%q is not a player link", ref)
+	}
+
+	return u.Scheme + "://" + u.Host + prefix, token, nil
+}
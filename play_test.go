@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseGameRef(t *testing.T) {
+	tests := []struct {
+		ref       string
+		wantBase  string
+		wantToken string
+		wantErr   bool
+	}{
+		{ref: "hungry-hippo", wantBase: "http://localhost:8080", wantToken: "hungry-hippo"},
+		{ref: "https://wording.example/game/hungry-hippo", wantBase: "https://wording.example", wantToken: "hungry-hippo"},
+		{ref: "https://example.com/wording/game/hungry-hippo", wantBase: "https://example.com/wording", wantToken: "hungry-hippo"},
+		{ref: "https://wording.example/manage/secret", wantErr: true},
+		{ref: "game/hungry-hippo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			base, token, err := parseGameRef(tt.ref, "http://localhost:8080/")
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantBase, base)
+			assert.Equal(t, tt.wantToken, token)
+		})
+	}
+}
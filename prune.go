@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/service"
+)
+
+// prune deletes idle games once, for operators who would rather run it from
+// a cron job than from the server.
+func (a *app) prune(ctx context.Context, args []string) error {
+	fs := a.flagSet("prune")
+	idleAfter := fs.Duration("idle-after", a.cfg.Retention.IdleAfter, "Delete games idle for longer than this")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return a.usageError("prune: unexpected arguments %q", fs.Args())
+	}
+	if *idleAfter <= 0 {
+		return a.usageError("prune: -idle-after (or retention-idle-after) must be positive")
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	n, err := svc.PruneGames(ctx, time.Now().Add(-*idleAfter))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "pruned %d games\n", n)
+
+	return nil
+}
+
+// pruneEvery periodically deletes games that have been idle for longer than
+// idleAfter, until ctx is cancelled.
+func pruneEvery(ctx context.Context, svc service.Service, idleAfter, interval time.Duration, logger log.FieldLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := svc.PruneGames(ctx, time.Now().Add(-idleAfter))
+		if err != nil {
+			logger.WithError(err).Warn("pruning idle games")
+			continue
+		}
+		if n > 0 {
+			logger.WithField("games", n).Info("pruned idle games")
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/connorkuehl/wording/internal/generator"
+	"github.com/connorkuehl/wording/internal/health"
+	"github.com/connorkuehl/wording/internal/logging"
+	"github.com/connorkuehl/wording/internal/metrics"
+	"github.com/connorkuehl/wording/internal/ratelimit"
+	"github.com/connorkuehl/wording/internal/server"
+	"github.com/connorkuehl/wording/internal/service"
+	"github.com/connorkuehl/wording/internal/signer"
+	"github.com/connorkuehl/wording/internal/store"
+	"github.com/connorkuehl/wording/internal/tracing"
+)
+
+// serve runs the web server until ctx is cancelled, then drains in-flight
+// requests.
+func serve(ctx context.Context, a *app) error {
+	cfg, logger := a.cfg, a.log
+
+	err := cfg.ValidateServe()
+	if err != nil {
+		return err
+	}
+
+	logger.WithFields(toFields(cfg.Redacted())).Info("loaded configuration")
+
+	recentErrors := logging.NewRecentErrors(recentErrorsKept)
+	logger.AddHook(recentErrors)
+
+	// Background work outlives the signal so that in-flight requests still
+	// have working rate limiters while they drain.
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	defer func() {
+		stopBackground()
+		background.Wait()
+	}()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		Environment: cfg.Environment,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := shutdownTracing(ctx)
+		if err != nil {
+			logger.WithError(err).Warn("flushing traces")
+		}
+	}()
+
+	answerSealer, err := a.sealer()
+	if err != nil {
+		return err
+	}
+	if answerSealer == nil {
+		logger.Warn("no answer-keys configured; answers will be stored in plaintext")
+	}
+
+	store, err := store.NewPostgresStore(cfg.DBDSN, answerSealer, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := store.Close()
+		if err != nil {
+			logger.WithError(err).Warn("closing database")
+			return
+		}
+		logger.Info("closed database")
+	}()
+
+	logger.Info("connected to database")
+
+	m := metrics.New()
+
+	checker := health.New(cfg.Health.CheckTimeout)
+	checker.Register("postgres", true, store.Ping)
+
+	adminTokenGenerator := generator.NewUUIDGenerator()
+
+	var gameTokenGenerator service.TokenGenerator = generator.NewUUIDGenerator()
+	if cfg.WordGenSvc != "" {
+		humanReadable := newHumanReadableGenerator(cfg.WordGenSvc, m.FallibleTokener)
+		gameTokenGenerator = humanReadable
+
+		// Game tokens fall back to UUIDs, so the word generator being down
+		// degrades the experience but shouldn't take replicas out of
+		// rotation.
+		checker.Register("randword", false, humanReadable.Status)
+	}
+
+	keys := cfg.CookieKeys.Bytes()
+	if len(keys) == 0 {
+		logger.Warn("no cookie-keys configured, generating a temporary one; player cookies will not survive a restart")
+		key := make([]byte, 32)
+		_, err = rand.Read(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	cookieSigner, err := signer.New(keys...)
+	if err != nil {
+		return err
+	}
+
+	rater, err := a.rater()
+	if err != nil {
+		return err
+	}
+
+	mailer, closeMail, err := a.mailer()
+	if err != nil {
+		return err
+	}
+	defer closeMail()
+
+	operatorAuth, err := a.operatorAuth()
+	if err != nil {
+		return err
+	}
+	if _, ok := operatorAuth.(server.StubAuth); ok {
+		logger.Warn("admin-auth is stub; anyone can use the admin console")
+	}
+
+	svc := m.Service(tracing.Service(service.New(
+		m.Store(tracing.Store(store)),
+		adminTokenGenerator,
+		gameTokenGenerator,
+		rater,
+		mailer,
+		logger,
+	)))
+	m.RegisterStats(svc.Stats)
+
+	srv := server.New(cfg.BaseURL, svc, cookieSigner, logger)
+
+	limit := func(rule ratelimit.Rule, key server.KeyFunc) func(http.Handler) http.Handler {
+		limiter := ratelimit.NewMemory(rule)
+
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			limiter.Run(backgroundCtx)
+		}()
+
+		return srv.RateLimit(limiter, key)
+	}
+
+	if cfg.Retention.IdleAfter > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			pruneEvery(backgroundCtx, svc, cfg.Retention.IdleAfter, cfg.Retention.PruneInterval, logger)
+		}()
+	}
+
+	// The web and the API share limiters so that switching between them
+	// doesn't double anyone's allowance.
+	createLimit := limit(cfg.Limits.CreateGameIP, server.ByIP)
+	loginLimit := limit(cfg.Limits.LoginIP, server.ByIP)
+	guessLimits := []func(http.Handler) http.Handler{
+		limit(cfg.Limits.GuessIP, server.ByIP),
+		limit(cfg.Limits.GuessPlayer, server.ByPlayer),
+		limit(cfg.Limits.GuessGame, server.ByGame),
+	}
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.RealIP)
+	router.Use(middleware.Recoverer)
+	router.Use(m.Middleware)
+	router.Use(tracing.RouteName)
+
+	router.Get("/healthz", checker.Live)
+	router.Get("/health", checker.Live)
+	router.Get("/readyz", checker.Ready)
+
+	router.Group(func(router chi.Router) {
+		router.Use(logging.Middleware(logger))
+		router.Use(srv.PlayerIdentity)
+		router.Use(srv.CSRF)
+
+		router.Get("/", srv.Home)
+		router.Get("/manage/{admin_token}", srv.ManageGame)
+		router.Get("/manage/{admin_token}/export", srv.ExportGame)
+		router.With(createLimit).Post("/games", srv.CreateGame)
+		router.With(logging.Game).Get("/game/{token}", srv.PlayGame)
+		router.With(logging.Game).With(guessLimits...).Post("/game/{token}", srv.Guess)
+		router.Post("/manage/{admin_token}/delete", srv.DeleteGame)
+		router.Post("/manage/{admin_token}/rotate", srv.RotateAdminLink)
+		router.Post("/manage/{admin_token}/recovery", srv.SetRecoveryEmail)
+		router.Post("/manage/{admin_token}/settings", srv.UpdateGameSettings)
+		router.Get("/me", srv.Me)
+		router.Get("/me/export", srv.ExportHistory)
+		router.Post("/me/clear", srv.ClearHistory)
+		router.Get("/my-games", srv.MyGames)
+		router.Post("/my-games/delete", srv.DeleteMyGames)
+		router.Get("/login", srv.LoginPage)
+		router.With(loginLimit).Post("/login", srv.RequestLogin)
+		router.Get("/login/{token}", srv.ConfirmLogin)
+		router.Post("/login/{token}", srv.Login)
+		router.Post("/logout", srv.Logout)
+		router.Get("/recover", srv.RecoverPage)
+		router.With(loginLimit).Post("/recover", srv.RequestRecovery)
+		router.Get("/recover/{token}", srv.ConfirmRecovery)
+		router.With(loginLimit).Post("/recover/{token}", srv.RecoverGames)
+
+		if operatorAuth != nil {
+			admin := server.NewAdmin(cfg.BaseURL, svc, operatorAuth, recentErrors, logger)
+
+			router.Route("/admin", func(router chi.Router) {
+				router.Use(admin.Authenticate)
+
+				router.Get("/", admin.Overview)
+				router.Get("/games", admin.Games)
+				router.Get("/games/{admin_token}", admin.Game)
+				router.Post("/games/{admin_token}/disable", admin.DisableGame)
+				router.Post("/games/{admin_token}/enable", admin.EnableGame)
+				router.Post("/games/{admin_token}/delete", admin.DeleteGame)
+			})
+		}
+	})
+
+	router.Route("/api", func(router chi.Router) {
+		router.Use(logging.Middleware(logger))
+		router.Use(srv.APIPlayerIdentity)
+
+		router.With(createLimit).Post("/games", srv.APICreateGame)
+		router.With(logging.Game).Get("/games/{token}", srv.APIGame)
+		router.With(logging.Game).With(guessLimits...).Post("/games/{token}/guesses", srv.APIGuess)
+		router.Get("/stats", srv.APIStats)
+		router.Get("/manage/{admin_token}/stats", srv.APIGameStats)
+		router.Get("/me", srv.APIMe)
+		router.Get("/me/history", srv.APIHistory)
+		router.Delete("/me/history", srv.APIClearHistory)
+	})
+
+	httpServer := &http.Server{
+		Addr:              cfg.BindAddr,
+		Handler:           tracing.Handler(router),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	// Metrics are served on their own address so that they can be kept off
+	// the public internet.
+	var metricsServer *http.Server
+	if cfg.MetricsAddr != "" {
+		metricsRouter := chi.NewRouter()
+		metricsRouter.Handle("/metrics", m.Handler())
+
+		metricsServer = &http.Server{
+			Addr:              cfg.MetricsAddr,
+			Handler:           metricsRouter,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		}
+	}
+
+	serveErr := make(chan error, 2)
+	go func() {
+		logger.Info("listening")
+		serveErr <- httpServer.ListenAndServe()
+	}()
+	if metricsServer != nil {
+		go func() {
+			logger.WithField("addr", cfg.MetricsAddr).Info("serving metrics")
+			serveErr <- metricsServer.ListenAndServe()
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	checker.ShutDown()
+
+	logger.WithField("delay", cfg.Server.ShutdownDelay).Info("shutting down, reporting unready")
+	time.Sleep(cfg.Server.ShutdownDelay)
+
+	logger.WithField("timeout", cfg.Server.ShutdownTimeout).Info("draining in-flight requests")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// Both servers are shut down even if draining the first fails, so the
+	// metrics listener is never left open.
+	err = httpServer.Shutdown(shutdownCtx)
+	if metricsServer != nil {
+		err = errors.Join(err, metricsServer.Shutdown(shutdownCtx))
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("drained in-flight requests")
+
+	return nil
+}
+
+// recentErrorsKept is how many errors the admin console shows.
+const recentErrorsKept = 50
+
+func toFields(m map[string]string) log.Fields {
+	f := make(log.Fields, len(m))
+	for k, v := range m {
+		f[k] = v
+	}
+	return f
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/connorkuehl/wording/internal/solver"
+	"github.com/connorkuehl/wording/internal/wording"
+)
+
+// solve helps puzzle authors see how hard an answer is, or what's left to
+// guess part way through a game.
+func (a *app) solve(ctx context.Context, args []string) error {
+	defaultWords := os.Getenv("WORDING_WORDS")
+	if defaultWords == "" {
+		defaultWords = "/usr/share/dict/words"
+	}
+
+	fs := a.flagSet("solve")
+	wordsPath := fs.String("words", defaultWords, "Word list with one word per line (env WORDING_WORDS)")
+	answer := fs.String("answer", "", "Show how the solver would find this answer")
+	length := fs.Int("length", 0, "Answer length, if no attempts are given")
+	top := fs.Int("top", 10, "How many guesses to suggest")
+	hard := fs.Bool("hard", false, "Only suggest guesses that could be the answer")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), `Usage: wording solve [flags] [GUESS=FEEDBACK...]
+
+Attempts are written as the guess and one mark per letter: g for correct,
+y for in the wrong place and . for not in the answer, e.g. tomato=yg.ggg.
+
+`)
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+
+	state := &wording.GameState{}
+	for _, arg := range fs.Args() {
+		attempt, err := solver.ParseAttempt(arg)
+		if err != nil {
+			return a.usageError("solve: %v", err)
+		}
+		state.Attempts = append(state.Attempts, attempt)
+	}
+
+	s, err := loadSolver(*wordsPath)
+	if err != nil {
+		return err
+	}
+
+	if *answer != "" {
+		return a.printSolution(s, *answer)
+	}
+
+	n := *length
+	if len(state.Attempts) > 0 {
+		n = len(state.Attempts[0])
+	}
+	for _, attempt := range state.Attempts {
+		if len(attempt) != n {
+			return a.usageError("solve: every attempt must be %d letters long", n)
+		}
+	}
+	if n <= 0 {
+		return a.usageError("solve: give -answer, -length or at least one attempt")
+	}
+
+	candidates := s.Candidates(n, state)
+	if len(candidates) == 0 {
+		fmt.Fprintln(a.stdout, "No word in the list fits.")
+		return nil
+	}
+
+	fmt.Fprintf(a.stdout, "%d candidates: %s\n\n", len(candidates), abbreviate(candidates, 20))
+
+	pool := candidates
+	if !*hard {
+		pool = s.Words(n)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GUESS\tBITS\tEXPECTED LEFT\tCOULD WIN")
+	for _, g := range solver.Rank(candidates, pool, *top) {
+		fmt.Fprintf(w, "%s\t%.2f\t%.1f\t%t\n", g.Word, g.Bits, g.Remaining, g.Candidate)
+	}
+	return w.Flush()
+}
+
+func (a *app) printSolution(s *solver.Solver, answer string) error {
+	err := wording.ValidateAnswer(answer)
+	if err != nil {
+		return a.usageError("solve: %v", err)
+	}
+
+	guesses := s.Solve(answer, 0)
+	for i, guess := range guesses {
+		fmt.Fprintf(a.stdout, "%d. %s\n", i+1, solver.FormatAttempt(wording.Evaluate(strings.ToLower(answer), guess)))
+	}
+
+	fmt.Fprintf(a.stdout, "\nSolved in %d guesses with %d words of that length to choose from.\n", len(guesses), len(s.Words(len(answer))))
+
+	d := solver.NewRater(s).RateDifficulty(answer)
+	fmt.Fprintf(a.stdout, "Difficulty: %d/100 (%s). Players will need about %d guesses.\n", d.Score, d.Label(), d.ExpectedGuesses())
+
+	return nil
+}
+
+func abbreviate(words []string, n int) string {
+	if len(words) <= n {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:n], " ") + fmt.Sprintf(" ... (%d more)", len(words)-n)
+}
+
+// loadSolver reads the word list at path.
+func loadSolver(path string) (*solver.Solver, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	words, counts, err := solver.ReadWords(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return solver.New(words, counts), nil
+}
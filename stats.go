@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"text/tabwriter"
+)
+
+// stats prints the lifetime stats shown on the home page.
+func (a *app) stats(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return a.usageError("stats: unexpected arguments %q", args)
+	}
+
+	svc, closeService, err := a.openService()
+	if err != nil {
+		return err
+	}
+	defer closeService()
+
+	stats, err := svc.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 1, ' ', 0)
+	printStats(w, stats)
+	return w.Flush()
+}